@@ -0,0 +1,216 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// baseline is a saved comparison that later runs are checked against
+type baseline struct {
+	Name    string       `json:"name"`
+	Created time.Time    `json:"created"`
+	Seed    int64        `json:"seed"`
+	Trials  int          `json:"trials"`
+	Results []trialStats `json:"results"`
+}
+
+// saveBaseline writes results to path, naming the baseline after the file
+func saveBaseline(path string, seed int64, trials int, results []trialStats) error {
+	b := baseline{
+		Name:    strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
+		Created: time.Now().UTC(),
+		Seed:    seed,
+		Trials:  trials,
+		Results: results,
+	}
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// loadBaseline reads a baseline saved by saveBaseline
+func loadBaseline(path string) (*baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var b baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("reading baseline %s: %w", path, err)
+	}
+	return &b, nil
+}
+
+// regression is a metric of one (algorithm, N) pair that got significantly
+// worse than the baseline
+type regression struct {
+	Algorithm string  `json:"algorithm"`
+	N         int     `json:"n"`
+	Metric    string  `json:"metric"` // "time", "memory" or "success"
+	Baseline  float64 `json:"baseline"`
+	Current   float64 `json:"current"`
+}
+
+func (r regression) String() string {
+	change := fmt.Sprintf("%+.0f%%", 100*(r.Current/r.Baseline-1))
+	switch r.Metric {
+	case "success":
+		return fmt.Sprintf("%s N=%d: success rate %.0f%% -> %.0f%%",
+			r.Algorithm, r.N, 100*r.Baseline, 100*r.Current)
+	case "time":
+		return fmt.Sprintf("%s N=%d: mean time %v -> %v (%s)",
+			r.Algorithm, r.N, duration(r.Baseline), duration(r.Current), change)
+	}
+	return fmt.Sprintf("%s N=%d: mean %s %d KB -> %d KB (%s)",
+		r.Algorithm, r.N, r.Metric, kilobytes(r.Baseline), kilobytes(r.Current), change)
+}
+
+// checkBaseline compares every pair run by both results and the baseline.
+// A metric regresses when it is worse by more than threshold (a fraction of
+// the baseline mean) and the difference is significant at the 5% level: a
+// one-sided Welch t-test for time and memory, a one-sided two-proportion
+// z-test for the success rate. Pairs missing from either side, skipped on
+// either side or run only once are listed in untested, with the reason.
+func checkBaseline(base *baseline, results []trialStats, threshold float64) (regressions []regression, untested []string) {
+	index := make(map[string]trialStats, len(base.Results))
+	for _, s := range base.Results {
+		index[pairKey(s.Algorithm, s.N)] = s
+	}
+
+	ran := make(map[string]bool, len(results))
+	for _, cur := range results {
+		key := pairKey(cur.Algorithm, cur.N)
+		ran[key] = true
+		old, ok := index[key]
+		switch {
+		case !ok:
+			untested = append(untested, key+" (not in baseline)")
+			continue
+		case cur.Skipped || old.Skipped:
+			untested = append(untested, key+" (skipped)")
+			continue
+		case cur.Trials < 2 || old.Trials < 2:
+			untested = append(untested, key+" (needs 2 trials)")
+			continue
+		}
+
+		metrics := []struct {
+			name     string
+			old, cur []float64
+		}{
+			{"time", runDurations(old.Runs), runDurations(cur.Runs)},
+			{"memory", runAllocs(old.Runs), runAllocs(cur.Runs)},
+		}
+		for _, m := range metrics {
+			oldMean, curMean := mean(m.old), mean(m.cur)
+			if curMean > oldMean*(1+threshold) && welchSignificant(m.old, m.cur) {
+				regressions = append(regressions, regression{cur.Algorithm, cur.N, m.name, oldMean, curMean})
+			}
+		}
+
+		if cur.SuccessRate < old.SuccessRate-threshold &&
+			proportionSignificant(old.Successes, old.Trials, cur.Successes, cur.Trials) {
+			regressions = append(regressions, regression{cur.Algorithm, cur.N, "success", old.SuccessRate, cur.SuccessRate})
+		}
+	}
+
+	for _, old := range base.Results {
+		if key := pairKey(old.Algorithm, old.N); !ran[key] {
+			untested = append(untested, key+" (not in this run)")
+		}
+	}
+	return regressions, untested
+}
+
+// printBaselineCheck reports the outcome of checkBaseline
+func printBaselineCheck(w io.Writer, base *baseline, regressions []regression, untested []string) {
+	fmt.Fprintf(w, "\nRegression check against baseline %q (%s)\n", base.Name, base.Created.Format(time.RFC3339))
+	for _, r := range regressions {
+		fmt.Fprintf(w, "  REGRESSION %v\n", r)
+	}
+	for _, pair := range untested {
+		fmt.Fprintf(w, "  not checked: %s\n", pair)
+	}
+	if len(regressions) == 0 {
+		fmt.Fprintln(w, "  no significant regressions")
+	}
+}
+
+func pairKey(algorithm string, n int) string {
+	return fmt.Sprintf("%s N=%d", algorithm, n)
+}
+
+func runDurations(runs []runResult) []float64 {
+	sample := make([]float64, len(runs))
+	for i, r := range runs {
+		sample[i] = float64(r.DurationNS)
+	}
+	return sample
+}
+
+func runAllocs(runs []runResult) []float64 {
+	sample := make([]float64, len(runs))
+	for i, r := range runs {
+		sample[i] = float64(r.TotalAlloc)
+	}
+	return sample
+}
+
+// welchSignificant reports whether cur has a larger mean than old at the 5%
+// level by a one-sided Welch t-test, which does not assume equal variances.
+// Both samples need at least two values.
+func welchSignificant(old, cur []float64) bool {
+	n1, n2 := float64(len(old)), float64(len(cur))
+	a, b := variance(old)/n1, variance(cur)/n2
+	diff := mean(cur) - mean(old)
+	if a+b == 0 {
+		// No spread on either side, e.g. allocations of a deterministic
+		// search: any increase is real
+		return diff > 0
+	}
+
+	t := diff / math.Sqrt(a+b)
+	df := (a + b) * (a + b) / (a*a/(n1-1) + b*b/(n2-1))
+	return t > tQuantile95(int(df))
+}
+
+// proportionSignificant reports whether the success rate k2/n2 is lower
+// than k1/n1 at the 5% level by a one-sided pooled two-proportion z-test
+func proportionSignificant(k1, n1, k2, n2 int) bool {
+	p1, p2 := float64(k1)/float64(n1), float64(k2)/float64(n2)
+	pooled := float64(k1+k2) / float64(n1+n2)
+	se := math.Sqrt(pooled * (1 - pooled) * (1/float64(n1) + 1/float64(n2)))
+	if se == 0 {
+		return false
+	}
+	return (p1-p2)/se > 1.645
+}
+
+// tTable95 holds the 95% quantiles of Student's t distribution for 1 to 30
+// degrees of freedom, used for one-sided tests at the 5% level
+var tTable95 = [...]float64{
+	6.314, 2.920, 2.353, 2.132, 2.015, 1.943, 1.895, 1.860, 1.833, 1.812,
+	1.796, 1.782, 1.771, 1.761, 1.753, 1.746, 1.740, 1.734, 1.729, 1.725,
+	1.721, 1.717, 1.714, 1.711, 1.708, 1.706, 1.703, 1.701, 1.699, 1.697,
+}
+
+// tQuantile95 returns the 95% t quantile for df degrees of freedom (rounded
+// down, which errs on the side of fewer false alarms), falling back to the
+// normal quantile beyond the table
+func tQuantile95(df int) float64 {
+	switch {
+	case df < 1:
+		return tTable95[0]
+	case df <= len(tTable95):
+		return tTable95[df-1]
+	}
+	return 1.645
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestWelchSignificant(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, cur []float64
+		want     bool
+	}{
+		{"same sample", []float64{10, 11, 9, 10}, []float64{10, 11, 9, 10}, false},
+		{"clearly slower", []float64{10, 11, 9, 10}, []float64{20, 21, 19, 20}, true},
+		{"faster", []float64{20, 21, 19, 20}, []float64{10, 11, 9, 10}, false},
+		{"increase within noise", []float64{1, 10, 1, 10}, []float64{2, 11, 2, 11}, false},
+		{"no spread, increase", []float64{5, 5}, []float64{6, 6}, true},
+		{"no spread, equal", []float64{5, 5, 5}, []float64{5, 5}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := welchSignificant(tt.old, tt.cur); got != tt.want {
+				t.Errorf("welchSignificant(%v, %v) = %v, want %v", tt.old, tt.cur, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProportionSignificant(t *testing.T) {
+	tests := []struct {
+		k1, n1, k2, n2 int
+		want           bool
+	}{
+		{10, 10, 10, 10, false}, // No variance at all
+		{0, 10, 0, 10, false},
+		{10, 10, 0, 10, true},
+		{10, 10, 9, 10, false}, // One failure in ten is not significant
+		{50, 50, 40, 50, true},
+		{5, 10, 10, 10, false}, // Improvement
+	}
+	for _, tt := range tests {
+		if got := proportionSignificant(tt.k1, tt.n1, tt.k2, tt.n2); got != tt.want {
+			t.Errorf("proportionSignificant(%d/%d, %d/%d) = %v, want %v",
+				tt.k1, tt.n1, tt.k2, tt.n2, got, tt.want)
+		}
+	}
+}
+
+// trials builds the statistics of runs of algorithm on N with the given
+// durations, each allocating alloc bytes; the first successes runs succeed
+func trials(algorithm string, n, successes int, alloc uint64, durations ...int64) trialStats {
+	s := trialStats{Algorithm: algorithm, N: n, Trials: len(durations), Successes: successes}
+	for i, d := range durations {
+		s.Runs = append(s.Runs, runResult{
+			Algorithm:  algorithm,
+			N:          n,
+			Success:    i < successes,
+			DurationNS: d,
+			TotalAlloc: alloc,
+		})
+	}
+	s.SuccessRate = float64(successes) / float64(len(durations))
+	return s
+}
+
+func TestCheckBaseline(t *testing.T) {
+	base := &baseline{Results: []trialStats{
+		trials("sa", 8, 10, 1000, 100, 110, 90, 100, 105, 95, 100, 100, 102, 98),
+		trials("ga", 8, 3, 1000, 100, 100, 100),
+		trials("mc", 8, 1, 1000, 100),
+		{Algorithm: "dfs", N: 30, Skipped: true},
+		trials("pt", 8, 2, 1000, 100, 100),
+	}}
+	same := base.Results[0]
+
+	tests := []struct {
+		name        string
+		results     []trialStats
+		threshold   float64
+		regressions []string
+		untested    []string
+	}{
+		{
+			name:      "unchanged",
+			results:   []trialStats{same},
+			threshold: 0.1,
+			untested:  []string{"ga N=8 (not in this run)", "mc N=8 (not in this run)", "dfs N=30 (not in this run)", "pt N=8 (not in this run)"},
+		},
+		{
+			name: "slower, more memory, fewer successes",
+			results: []trialStats{
+				trials("sa", 8, 2, 2000, 200, 220, 180, 200, 210, 190, 200, 200, 204, 196),
+				trials("ga", 8, 3, 1000, 100, 100, 100),
+				trials("mc", 8, 1, 1000, 100, 100),
+				{Algorithm: "dfs", N: 30, Skipped: true},
+				trials("pt", 8, 2, 1000, 100, 100),
+			},
+			threshold:   0.1,
+			regressions: []string{"sa N=8 time", "sa N=8 memory", "sa N=8 success"},
+			untested:    []string{"mc N=8 (needs 2 trials)", "dfs N=30 (skipped)"},
+		},
+		{
+			name: "worse within the threshold",
+			results: []trialStats{
+				trials("ga", 8, 3, 1050, 105, 105, 105),
+				trials("pt", 8, 2, 1000, 100, 100),
+			},
+			threshold: 0.1,
+			untested:  []string{"sa N=8 (not in this run)", "mc N=8 (not in this run)", "dfs N=30 (not in this run)"},
+		},
+		{
+			name: "worse beyond a lower threshold",
+			results: []trialStats{
+				trials("ga", 8, 3, 1050, 105, 105, 105),
+				trials("pt", 8, 2, 1000, 100, 100),
+			},
+			threshold:   0.01,
+			regressions: []string{"ga N=8 time", "ga N=8 memory"},
+			untested:    []string{"sa N=8 (not in this run)", "mc N=8 (not in this run)", "dfs N=30 (not in this run)"},
+		},
+		{
+			name: "new pairs",
+			results: []trialStats{
+				same,
+				trials("tabu", 8, 3, 1000, 100, 100, 100),
+				{Algorithm: "dfs", N: 40, Skipped: true},
+				trials("ga", 8, 3, 1000, 100, 100, 100),
+				trials("mc", 8, 1, 1000, 100),
+				{Algorithm: "dfs", N: 30, Skipped: true},
+				trials("pt", 8, 2, 1000, 100, 100),
+			},
+			threshold: 0.1,
+			untested:  []string{"tabu N=8 (not in baseline)", "dfs N=40 (not in baseline)", "mc N=8 (needs 2 trials)", "dfs N=30 (skipped)"},
+		},
+		{
+			name: "skipped now, run in the baseline",
+			results: []trialStats{
+				same,
+				{Algorithm: "ga", N: 8, Skipped: true},
+				trials("mc", 8, 1, 1000, 100),
+				{Algorithm: "dfs", N: 30, Skipped: true},
+				trials("pt", 8, 2, 1000, 100, 100),
+			},
+			threshold: 0.1,
+			untested:  []string{"ga N=8 (skipped)", "mc N=8 (needs 2 trials)", "dfs N=30 (skipped)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			regressions, untested := checkBaseline(base, tt.results, tt.threshold)
+			var got []string
+			for _, r := range regressions {
+				got = append(got, fmt.Sprintf("%s N=%d %s", r.Algorithm, r.N, r.Metric))
+			}
+			if !slices.Equal(got, tt.regressions) {
+				t.Errorf("regressions = %q, want %q", got, tt.regressions)
+			}
+			if !slices.Equal(untested, tt.untested) {
+				t.Errorf("untested = %q, want %q", untested, tt.untested)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"nqueen/nqueens"
+)
+
+// trialStats summarizes repeated runs of one algorithm on one board size
+type trialStats struct {
+	Algorithm   string      `json:"algorithm"`
+	N           int         `json:"n"`
+	Skipped     bool        `json:"skipped,omitempty"`
+	Trials      int         `json:"trials"`
+	Successes   int         `json:"successes"`
+	SuccessRate float64     `json:"success_rate"`
+	SuccessCI   [2]float64  `json:"success_ci"` // 95% Wilson score interval
+	TimeNS      summary     `json:"time_ns"`
+	TotalAlloc  summary     `json:"total_alloc"`
+	Runs        []runResult `json:"runs"`
+}
+
+// summary describes a sample of measurements
+type summary struct {
+	Mean   float64    `json:"mean"`
+	Median float64    `json:"median"`
+	P95    float64    `json:"p95"`
+	StdDev float64    `json:"stddev"`  // Sample standard deviation
+	MeanCI [2]float64 `json:"mean_ci"` // 95% confidence interval of the mean
+}
+
+// trialSeeds derives one seed per trial from base. The first trial uses base
+// itself, so a single trial replays exactly with "solve -seed base"; the
+// others are drawn from a source seeded with base and reported per run.
+func trialSeeds(base int64, trials int) []int64 {
+	seeds := []int64{base}
+	used := map[int64]bool{base: true}
+	rng := rand.New(rand.NewSource(base))
+	for len(seeds) < trials {
+		seed := rng.Int63()
+		if seed != 0 && !used[seed] {
+			used[seed] = true
+			seeds = append(seeds, seed)
+		}
+	}
+	return seeds[:trials]
+}
+
+// runTrials runs algo once per seed and summarizes the runs. It also returns
+// the solver of the first successful run, for printing its board.
+func runTrials(algo nqueens.Algorithm, n int, opts nqueens.Options, timeout time.Duration, seeds []int64) (trialStats, nqueens.Solver) {
+	stats := trialStats{Algorithm: algo.Name, N: n, Trials: len(seeds)}
+	var solved nqueens.Solver
+	times := make([]float64, 0, len(seeds))
+	allocs := make([]float64, 0, len(seeds))
+
+	for i, seed := range seeds {
+		opts.Seed = seed
+		result, solver := measureRun(algo, n, opts, timeout)
+		result.Trial = i + 1
+		stats.Runs = append(stats.Runs, result)
+		if result.Success {
+			stats.Successes++
+			if solved == nil {
+				solved = solver
+			}
+		}
+		times = append(times, float64(result.DurationNS))
+		allocs = append(allocs, float64(result.TotalAlloc))
+	}
+
+	stats.SuccessRate = float64(stats.Successes) / float64(stats.Trials)
+	stats.SuccessCI = wilsonInterval(stats.Successes, stats.Trials)
+	stats.TimeNS = summarize(times)
+	stats.TotalAlloc = summarize(allocs)
+	return stats, solved
+}
+
+// summarize computes the descriptive statistics of a non-empty sample
+func summarize(sample []float64) summary {
+	sorted := append([]float64(nil), sample...)
+	sort.Float64s(sorted)
+	k := len(sorted)
+
+	var s summary
+	s.Mean = mean(sorted)
+	s.Median = median(sorted)
+	s.P95 = percentile(sorted, 95)
+	if k > 1 {
+		s.StdDev = math.Sqrt(variance(sorted))
+	}
+	margin := tQuantile975(k-1) * s.StdDev / math.Sqrt(float64(k))
+	s.MeanCI = [2]float64{s.Mean - margin, s.Mean + margin}
+	return s
+}
+
+func mean(sample []float64) float64 {
+	var sum float64
+	for _, x := range sample {
+		sum += x
+	}
+	return sum / float64(len(sample))
+}
+
+// variance returns the sample variance; the sample needs two values
+func variance(sample []float64) float64 {
+	m := mean(sample)
+	var squares float64
+	for _, x := range sample {
+		squares += (x - m) * (x - m)
+	}
+	return squares / float64(len(sample)-1)
+}
+
+// median returns the middle value of a sorted sample, or the mean of the
+// two middle values when the sample has an even length
+func median(sorted []float64) float64 {
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// percentile returns the p-th percentile of a sorted sample by the
+// nearest-rank method
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// tTable975 holds the 97.5% quantiles of Student's t distribution for 1 to
+// 30 degrees of freedom, used for two-sided 95% intervals of small samples
+var tTable975 = [...]float64{
+	12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+	2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+	2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042,
+}
+
+// tQuantile975 returns the 97.5% t quantile for df degrees of freedom,
+// falling back to the normal quantile beyond the table. With no degrees of
+// freedom there is no spread to scale, so it returns 0.
+func tQuantile975(df int) float64 {
+	switch {
+	case df < 1:
+		return 0
+	case df <= len(tTable975):
+		return tTable975[df-1]
+	}
+	return 1.96
+}
+
+// wilsonInterval returns the 95% Wilson score interval of a success rate,
+// which unlike the normal approximation stays within [0, 1] and is
+// meaningful for 0 or all successes
+func wilsonInterval(successes, trials int) [2]float64 {
+	if trials == 0 {
+		return [2]float64{0, 1}
+	}
+	const z = 1.96
+	n := float64(trials)
+	p := float64(successes) / n
+	center := (p + z*z/(2*n)) / (1 + z*z/n)
+	margin := z / (1 + z*z/n) * math.Sqrt(p*(1-p)/n+z*z/(4*n*n))
+	return [2]float64{math.Max(0, center-margin), math.Min(1, center+margin)}
+}
+
+// printTrialStats prints the summary of repeated runs below a header line
+// in the comparison table format
+func printTrialStats(name string, s trialStats) {
+	fmt.Printf("%-20s: Success: %d/%d (%.0f%%, 95%% CI %.0f-%.0f%%)\n",
+		name, s.Successes, s.Trials, 100*s.SuccessRate, 100*s.SuccessCI[0], 100*s.SuccessCI[1])
+
+	t := s.TimeNS
+	fmt.Printf("%-20s  Time:   mean %v (95%% CI %v-%v), median %v, p95 %v, stddev %v\n", "",
+		duration(t.Mean), duration(math.Max(0, t.MeanCI[0])), duration(t.MeanCI[1]),
+		duration(t.Median), duration(t.P95), duration(t.StdDev))
+
+	m := s.TotalAlloc
+	fmt.Printf("%-20s  Memory: mean %d KB (95%% CI %d-%d KB), median %d KB, p95 %d KB, stddev %d KB\n", "",
+		kilobytes(m.Mean), kilobytes(math.Max(0, m.MeanCI[0])), kilobytes(m.MeanCI[1]),
+		kilobytes(m.Median), kilobytes(m.P95), kilobytes(m.StdDev))
+}
+
+func duration(ns float64) time.Duration {
+	return time.Duration(ns).Round(time.Microsecond)
+}
+
+func kilobytes(bytes float64) int64 {
+	return int64(bytes) / 1024
+}
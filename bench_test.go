@@ -0,0 +1,101 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPercentile(t *testing.T) {
+	sorted := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	tests := []struct {
+		sorted []float64
+		p      float64
+		want   float64
+	}{
+		{sorted, 0, 1},
+		{sorted, 10, 1},
+		{sorted, 11, 2},
+		{sorted, 50, 5},
+		{sorted, 95, 10},
+		{sorted, 100, 10},
+		{[]float64{7}, 50, 7},
+		{[]float64{7}, 95, 7},
+	}
+	for _, tt := range tests {
+		if got := percentile(tt.sorted, tt.p); got != tt.want {
+			t.Errorf("percentile(%v, %g) = %g, want %g", tt.sorted, tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestMedian(t *testing.T) {
+	tests := []struct {
+		sorted []float64
+		want   float64
+	}{
+		{[]float64{7}, 7},
+		{[]float64{1, 2}, 1.5},
+		{[]float64{1, 2, 9}, 2},
+		{[]float64{2, 4, 4, 4, 5, 5, 7, 9}, 4.5},
+	}
+	for _, tt := range tests {
+		if got := median(tt.sorted); got != tt.want {
+			t.Errorf("median(%v) = %g, want %g", tt.sorted, got, tt.want)
+		}
+	}
+}
+
+func TestWilsonInterval(t *testing.T) {
+	tests := []struct {
+		successes, trials int
+		want              [2]float64
+	}{
+		{0, 0, [2]float64{0, 1}},
+		{0, 10, [2]float64{0, 0.2775}},
+		{5, 10, [2]float64{0.2366, 0.7634}},
+		{10, 10, [2]float64{0.7225, 1}},
+		{95, 100, [2]float64{0.8883, 0.9785}},
+	}
+	for _, tt := range tests {
+		got := wilsonInterval(tt.successes, tt.trials)
+		if math.Abs(got[0]-tt.want[0]) > 1e-4 || math.Abs(got[1]-tt.want[1]) > 1e-4 {
+			t.Errorf("wilsonInterval(%d, %d) = %.4f, want %.4f", tt.successes, tt.trials, got, tt.want)
+		}
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		name   string
+		sample []float64
+		want   summary
+	}{
+		{
+			name:   "single value",
+			sample: []float64{3},
+			want:   summary{Mean: 3, Median: 3, P95: 3, MeanCI: [2]float64{3, 3}},
+		},
+		{
+			name:   "unsorted",
+			sample: []float64{9, 2, 5, 4, 4, 7, 4, 5},
+			want: summary{
+				Mean:   5,
+				Median: 4.5,
+				P95:    9,
+				StdDev: 2.1381,
+				MeanCI: [2]float64{3.2122, 6.7878}, // t(7) = 2.365
+			},
+		},
+	}
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-4 }
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := summarize(tt.sample)
+			if !near(got.Mean, tt.want.Mean) || !near(got.Median, tt.want.Median) ||
+				!near(got.P95, tt.want.P95) || !near(got.StdDev, tt.want.StdDev) ||
+				!near(got.MeanCI[0], tt.want.MeanCI[0]) || !near(got.MeanCI[1], tt.want.MeanCI[1]) {
+				t.Errorf("summarize(%v) = %+v, want %+v", tt.sample, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"nqueen/nqueens"
+)
+
+// commonFlags holds the flags shared by every subcommand
+type commonFlags struct {
+	timeout time.Duration
+	format  string
+	formats []string // Formats the command supports
+}
+
+// addCommonFlags registers the shared flags. formats lists the output
+// formats the command supports, text and json when none are given.
+func addCommonFlags(fs *flag.FlagSet, formats ...string) *commonFlags {
+	if len(formats) == 0 {
+		formats = []string{"text", "json"}
+	}
+	c := &commonFlags{formats: formats}
+	fs.DurationVar(&c.timeout, "timeout", 0, "time limit per run, e.g. 30s (0 means no limit)")
+	fs.StringVar(&c.format, "format", "text", "output format: "+strings.Join(formats, ", "))
+	return c
+}
+
+// setup validates the shared flags
+func (c *commonFlags) setup() error {
+	for _, format := range c.formats {
+		if c.format == format {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown output format %q (want %s)", c.format, strings.Join(c.formats, ", "))
+}
+
+// addAlgorithmFlags registers the seed and per-algorithm parameter flags,
+// and the -config file that loadConfig reads them from
+func addAlgorithmFlags(fs *flag.FlagSet) *nqueens.Options {
+	opts := &nqueens.Options{}
+	fs.String("config", "", "read algorithm parameters from this JSON `file`; flags override it")
+	fs.Int64Var(&opts.Seed, "seed", 0, "random seed; 0 picks one, which is reported for replay")
+	fs.IntVar(&opts.MaxIterations, "max-iter", 0, "maximum iterations (greedy, sa, mc, tabu, pt per replica)")
+	fs.IntVar(&opts.Restarts, "restarts", 0, "number of restarts (sa, ga, mc)")
+	fs.Float64Var(&opts.InitialTemp, "initial-temp", 0, "initial temperature (sa, default N×N)")
+	fs.Float64Var(&opts.CoolingRate, "cooling", 0, "geometric cooling rate (sa)")
+	fs.Float64Var(&opts.MinTemp, "min-temp", 0, "temperature at which a run stops (sa)")
+	fs.Float64Var(&opts.TargetAcceptance, "calibrate", 0, "calibrate the initial temperature to accept this share of uphill moves, e.g. 0.8 (sa)")
+	fs.Var(&mixValue{opts}, "mix", "neighbor strategy weights swap,conflicted,local (sa, default 0.6,0.2,0.2)")
+	fs.StringVar(&opts.Schedule, "schedule", "", "cooling schedule (sa): "+strings.Join(nqueens.CoolingScheduleNames(), ", "))
+	fs.IntVar(&opts.PopulationSize, "pop", 0, "population size (ga)")
+	fs.IntVar(&opts.Generations, "generations", 0, "maximum generations per run (ga)")
+	fs.Float64Var(&opts.MutationRate, "mutation", 0, "base mutation rate (ga)")
+	fs.Float64Var(&opts.CrossoverRate, "crossover", 0, "crossover rate (ga)")
+	fs.IntVar(&opts.Replicas, "replicas", 0, "number of concurrent replicas (pt)")
+	fs.IntVar(&opts.Tenure, "tenure", 0, "iterations a queen may not return to a row it left (tabu)")
+	return opts
+}
+
+// resolveSeed picks a seed when none was given, so that every run reports
+// the seed needed to replay it
+func resolveSeed(opts *nqueens.Options) {
+	for opts.Seed == 0 {
+		opts.Seed = rand.Int63()
+	}
+}
+
+// runResult is the outcome of a single timed solver run
+type runResult struct {
+	Algorithm  string `json:"algorithm"`
+	N          int    `json:"n"`
+	Seed       int64  `json:"seed,omitempty"`
+	Skipped    bool   `json:"skipped,omitempty"`
+	TimedOut   bool   `json:"timed_out,omitempty"`
+	Trial      int    `json:"trial,omitempty"` // 1-based trial number in a comparison
+	Success    bool   `json:"success"`
+	Invalid    bool   `json:"invalid,omitempty"` // Solver reported a board that failed validation
+	Conflicts  int    `json:"conflicts"`         // Conflicts left on the best board found
+	DurationNS int64  `json:"duration_ns"`
+	TotalAlloc uint64 `json:"total_alloc"`
+	HeapAlloc  uint64 `json:"heap_alloc"`
+	Solution   []int  `json:"solution,omitempty"`
+
+	Stats nqueens.SearchStats `json:"stats"`
+
+	Validation *nqueens.ValidationReport `json:"validation,omitempty"` // Set when a reported solution is invalid
+}
+
+// measureRun constructs and runs a solver, recording time and memory usage
+func measureRun(algo nqueens.Algorithm, n int, opts nqueens.Options, timeout time.Duration) (runResult, nqueens.Solver) {
+	var m1, m2 runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&m1)
+
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	solver := algo.New(n, opts)
+	success, err := solver.SolveContext(ctx)
+	duration := time.Since(start)
+
+	runtime.ReadMemStats(&m2)
+	result := runResult{
+		Algorithm:  algo.Name,
+		N:          n,
+		TimedOut:   errors.Is(err, context.DeadlineExceeded),
+		Success:    success,
+		DurationNS: duration.Nanoseconds(),
+		TotalAlloc: m2.TotalAlloc - m1.TotalAlloc,
+	}
+	if m2.HeapAlloc > m1.HeapAlloc {
+		result.HeapAlloc = m2.HeapAlloc - m1.HeapAlloc
+	}
+	if success {
+		result.Solution = solver.GetSolution()
+	}
+	_, result.Conflicts = solver.Best()
+	validateResult(&result)
+	if seeded, ok := solver.(nqueens.Seeded); ok {
+		result.Seed = seeded.Seed()
+	}
+	result.Stats = solver.Stats()
+	return result, solver
+}
+
+// validateResult checks a reported solution with nqueens.Validate rather
+// than trusting the solver's own cost function, turning a board that fails
+// into a failed run that carries the validation report
+func validateResult(r *runResult) {
+	if !r.Success {
+		return
+	}
+	report := nqueens.Validate(r.Solution)
+	if report.Valid && len(r.Solution) == r.N {
+		return
+	}
+	r.Success = false
+	r.Invalid = true
+	r.Conflicts = report.Total
+	r.Validation = &report
+}
+
+// printResultLine prints a run in the fixed-width comparison table format
+func printResultLine(name string, r runResult) {
+	if r.Skipped {
+		fmt.Printf("%-20s: Time: %12s, Memory: %8s, Success: %s\n",
+			name, "SKIPPED", "N/A", "N/A (too large)")
+		return
+	}
+
+	success := strconv.FormatBool(r.Success)
+	if r.TimedOut {
+		success = fmt.Sprintf("TIMEOUT (best: %d conflicts)", r.Conflicts)
+	}
+	if r.Invalid {
+		success = fmt.Sprintf("INVALID SOLUTION (%v)", r.Validation)
+	}
+	fmt.Printf("%-20s: Time: %12v, Memory: %8d KB (Heap: %d KB), Success: %s\n",
+		name, time.Duration(r.DurationNS), r.TotalAlloc/1024, r.HeapAlloc/1024, success)
+}
+
+// writeJSON writes v as indented JSON to w
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// lookupAlgorithms resolves a comma separated list of registry names
+func lookupAlgorithms(list string) ([]nqueens.Algorithm, error) {
+	if list == "" || list == "all" {
+		return nqueens.Algorithms(), nil
+	}
+
+	var algos []nqueens.Algorithm
+	for _, name := range strings.Split(list, ",") {
+		algo, ok := nqueens.Lookup(strings.TrimSpace(name))
+		if !ok {
+			return nil, fmt.Errorf("unknown algorithm %q (available: %s)",
+				name, strings.Join(nqueens.AlgorithmNames(), ", "))
+		}
+		algos = append(algos, algo)
+	}
+	return algos, nil
+}
+
+// expandSchedules validates the -schedule flag, a comma separated list of
+// cooling schedules. A list of several replaces simulated annealing with
+// one variant per schedule, named sa:<schedule>, so that compare runs every
+// schedule on the same sizes and seeds.
+func expandSchedules(algos []nqueens.Algorithm, opts *nqueens.Options) ([]nqueens.Algorithm, error) {
+	if opts.Schedule == "" {
+		return algos, nil
+	}
+	names := strings.Split(opts.Schedule, ",")
+	for i, name := range names {
+		names[i] = strings.TrimSpace(name)
+		if _, err := nqueens.NewCoolingSchedule(names[i]); err != nil {
+			return nil, err
+		}
+	}
+	if len(names) == 1 {
+		opts.Schedule = names[0]
+		return algos, nil
+	}
+	opts.Schedule = "" // Each variant sets its own
+
+	var expanded []nqueens.Algorithm
+	for _, algo := range algos {
+		if algo.Name != "sa" {
+			expanded = append(expanded, algo)
+			continue
+		}
+		for _, name := range names {
+			expanded = append(expanded, scheduleVariant(algo, name))
+		}
+	}
+	return expanded, nil
+}
+
+// scheduleVariant returns algo with its cooling schedule fixed to schedule
+func scheduleVariant(algo nqueens.Algorithm, schedule string) nqueens.Algorithm {
+	newSolver := algo.New
+	algo.Name += ":" + schedule
+	algo.DisplayName += " (" + schedule + ")"
+	algo.New = func(n int, opts nqueens.Options) nqueens.Solver {
+		opts.Schedule = schedule
+		return newSolver(n, opts)
+	}
+	return algo
+}
+
+// parseSizes parses a comma separated list of board sizes
+func parseSizes(list string) ([]int, error) {
+	var sizes []int
+	for _, field := range strings.Split(list, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid board size %q", field)
+		}
+		sizes = append(sizes, n)
+	}
+	return sizes, nil
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"nqueen/nqueens"
+)
+
+const defaultSizes = "10,15,20,30,50,100,200"
+
+// runCompare runs the selected algorithms over a list of board sizes
+func runCompare(args []string) (err error) {
+	fs := flag.NewFlagSet("compare", flag.ExitOnError)
+	sizesFlag := fs.String("sizes", defaultSizes, "comma separated board sizes")
+	algosFlag := fs.String("algos", "all", "comma separated algorithm names, or all")
+	noLimits := fs.Bool("no-limits", false, "run algorithms above their default maximum N")
+	trials := fs.Int("trials", 5, "runs per algorithm and board size, each with its own seed")
+	savePath := fs.String("save-baseline", "", "save the results as a baseline `file`, named after the file")
+	basePath := fs.String("baseline", "", "compare the results with a baseline `file` and fail on regressions")
+	threshold := fs.Float64("threshold", 0.1, "smallest relative change reported as a regression")
+	common := addCommonFlags(fs, recordFormats...)
+	outPath := addOutFlag(fs)
+	opts := addAlgorithmFlags(fs)
+	fs.Parse(args)
+
+	if err := common.setup(); err != nil {
+		return err
+	}
+	if err := loadConfig(fs, opts); err != nil {
+		return err
+	}
+	sizes, err := parseSizes(*sizesFlag)
+	if err != nil {
+		return err
+	}
+	algos, err := lookupAlgorithms(*algosFlag)
+	if err != nil {
+		return err
+	}
+	if algos, err = expandSchedules(algos, opts); err != nil {
+		return err
+	}
+	if err := validateOptions(opts, algos, sizes...); err != nil {
+		return err
+	}
+
+	if *trials < 1 {
+		return fmt.Errorf("invalid number of trials %d", *trials)
+	}
+
+	out, closeOut, err := openOutput(*outPath, common.format)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := closeOut(); err == nil {
+			err = cerr
+		}
+	}()
+	var records *recordWriter
+	if common.format == "csv" || common.format == "jsonl" {
+		if records, err = newRecordWriter(out, common.format); err != nil {
+			return err
+		}
+	}
+
+	var base *baseline
+	if *basePath != "" {
+		if base, err = loadBaseline(*basePath); err != nil {
+			return err
+		}
+		// Rerun the baseline's seeds so the runs are paired
+		if opts.Seed == 0 {
+			opts.Seed = base.Seed
+		}
+	}
+
+	resolveSeed(opts)
+	seeds := trialSeeds(opts.Seed, *trials)
+
+	if common.format == "text" {
+		fmt.Println("N-Queens Problem Solver - Basic Comparison")
+		fmt.Println("==========================================")
+		fmt.Printf("Seed: %d (replay a run with: solve -algo <name> -n <N> -seed %d)\n", opts.Seed, opts.Seed)
+		if *trials > 1 {
+			fmt.Printf("Trials: %d per algorithm and size; later trials derive their seeds from it (see -format json)\n", *trials)
+		}
+	}
+
+	var results []trialStats
+	for _, n := range sizes {
+		if common.format == "text" {
+			fmt.Printf("\nTesting N = %d\n", n)
+			fmt.Println(strings.Repeat("-", 50))
+		}
+
+		for _, algo := range algos {
+			var stats trialStats
+			var solver nqueens.Solver
+			if algo.MaxN > 0 && n > algo.MaxN && !*noLimits {
+				stats = trialStats{Algorithm: algo.Name, N: n, Skipped: true}
+			} else {
+				stats, solver = runTrials(algo, n, *opts, common.timeout, seeds)
+			}
+			results = append(results, stats)
+
+			if records != nil {
+				runs := stats.Runs
+				if stats.Skipped {
+					runs = []runResult{{Algorithm: algo.Name, N: n, Skipped: true}}
+				}
+				for _, run := range runs {
+					if err := records.write(run); err != nil {
+						return err
+					}
+				}
+			}
+
+			if common.format == "text" {
+				switch {
+				case stats.Skipped:
+					printResultLine(algo.DisplayName, runResult{Skipped: true})
+				case stats.Trials == 1:
+					printResultLine(algo.DisplayName, stats.Runs[0])
+				default:
+					printTrialStats(algo.DisplayName, stats)
+				}
+				// Show solution for small N values
+				if n <= 20 && solver != nil {
+					solver.PrintSolution()
+				}
+			}
+		}
+	}
+
+	if common.format == "json" {
+		if err := writeJSON(out, results); err != nil {
+			return err
+		}
+	}
+
+	if *savePath != "" {
+		if err := saveBaseline(*savePath, opts.Seed, *trials, results); err != nil {
+			return err
+		}
+	}
+	if base != nil {
+		regressions, untested := checkBaseline(base, results, *threshold)
+		// Keep machine-readable output on standard output parseable
+		report := os.Stdout
+		if common.format != "text" {
+			report = os.Stderr
+		}
+		printBaselineCheck(report, base, regressions, untested)
+		if len(regressions) > 0 {
+			return fmt.Errorf("%d regressions against baseline %q", len(regressions), base.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"nqueen/nqueens"
+)
+
+// loadConfig applies the -config file to opts. The file is the JSON form
+// of nqueens.Options, e.g. {"cooling_rate": 0.995, "restarts": 10}; flags
+// given on the command line take precedence over it.
+func loadConfig(fs *flag.FlagSet, opts *nqueens.Options) error {
+	path := fs.Lookup("config").Value.String()
+	if path == "" {
+		return nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// Remember the flags that were set, to reapply them over the file
+	set := make(map[string]string)
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = f.Value.String()
+	})
+	dec := json.NewDecoder(f)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(opts); err != nil {
+		return fmt.Errorf("config %s: %w", path, err)
+	}
+	for name, value := range set {
+		if err := fs.Set(name, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateOptions checks the algorithm parameters for every algorithm and
+// board size that will be run. Variants such as sa:<schedule> are checked
+// as the algorithm they are derived from.
+func validateOptions(opts *nqueens.Options, algos []nqueens.Algorithm, sizes ...int) error {
+	for _, algo := range algos {
+		name, _, _ := strings.Cut(algo.Name, ":")
+		for _, n := range sizes {
+			if err := opts.Validate(name, n); err != nil {
+				return fmt.Errorf("%s, N=%d: %w", algo.Name, n, err)
+			}
+		}
+	}
+	return nil
+}
+
+// mixValue is the -mix flag, three comma separated neighbor strategy weights
+type mixValue struct {
+	opts *nqueens.Options
+}
+
+func (v *mixValue) String() string {
+	if v == nil || v.opts == nil || v.opts.NeighborMix == nil {
+		return ""
+	}
+	m := v.opts.NeighborMix
+	return fmt.Sprintf("%g,%g,%g", m.Swap, m.Conflicted, m.Local)
+}
+
+func (v *mixValue) Set(s string) error {
+	fields := strings.Split(s, ",")
+	if len(fields) != 3 {
+		return fmt.Errorf("want three weights swap,conflicted,local, got %q", s)
+	}
+	var w [3]float64
+	for i, field := range fields {
+		f, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil {
+			return err
+		}
+		w[i] = f
+	}
+	v.opts.NeighborMix = &nqueens.NeighborMix{Swap: w[0], Conflicted: w[1], Local: w[2]}
+	return nil
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"time"
+
+	"nqueen/nqueens"
+)
+
+// countResult is the outcome of a solution counting run
+type countResult struct {
+	N              int     `json:"n"`
+	Solutions      int64   `json:"solutions"`
+	Expected       int64   `json:"expected,omitempty"` // Published count (OEIS A000170) when known
+	Unique         int64   `json:"unique,omitempty"`
+	ExpectedUnique int64   `json:"expected_unique,omitempty"` // Published count (OEIS A002562) when known
+	Fundamental    [][]int `json:"fundamental,omitempty"`
+	Verified       bool    `json:"verified"`
+	Workers        int     `json:"workers,omitempty"`
+	TimedOut       bool    `json:"timed_out,omitempty"`
+	DurationNS     int64   `json:"duration_ns"`
+}
+
+// runCount counts every solution for one board size
+func runCount(args []string) error {
+	fs := flag.NewFlagSet("count", flag.ExitOnError)
+	n := fs.Int("n", 8, "board size")
+	workers := fs.Int("workers", runtime.NumCPU(), "number of counting workers (ignored with -unique)")
+	unique := fs.Bool("unique", false, "also count fundamental solutions, unique up to symmetry")
+	list := fs.Bool("list", false, "list the fundamental solutions (implies -unique)")
+	common := addCommonFlags(fs)
+	fs.Parse(args)
+
+	if err := common.setup(); err != nil {
+		return err
+	}
+	if *n < 1 {
+		return fmt.Errorf("invalid board size %d", *n)
+	}
+	if *workers < 1 {
+		return fmt.Errorf("invalid worker count %d", *workers)
+	}
+	*unique = *unique || *list
+
+	start := time.Now()
+	solver := nqueens.NewExhaustiveSearchSolver(*n)
+	done := make(chan countResult, 1)
+	go func() {
+		if !*unique {
+			done <- countResult{Solutions: solver.CountSolutionsParallel(*workers), Workers: *workers}
+			return
+		}
+
+		var r countResult
+		var visit func(board []int) bool
+		if *list {
+			visit = func(board []int) bool {
+				r.Fundamental = append(r.Fundamental, append([]int(nil), board...))
+				return true
+			}
+		}
+		counts := solver.EnumerateFundamental(visit)
+		r.Solutions, r.Unique = counts.Total, counts.Unique
+		done <- r
+	}()
+
+	var result countResult
+	var timeout <-chan time.Time
+	if common.timeout > 0 {
+		timeout = time.After(common.timeout)
+	}
+	select {
+	case result = <-done:
+	case <-timeout:
+		result.TimedOut = true
+	}
+	result.N = *n
+	result.DurationNS = time.Since(start).Nanoseconds()
+
+	// Check the totals against the published sequences
+	var failure error
+	if result.TimedOut {
+		failure = fmt.Errorf("counting solutions for N=%d timed out after %v", *n, common.timeout)
+	}
+	expected, known := nqueens.KnownSolutionCount(*n)
+	expectedUnique, _ := nqueens.KnownFundamentalCount(*n)
+	if known && !result.TimedOut {
+		result.Expected = expected
+		result.Verified = result.Solutions == expected
+		if !result.Verified {
+			failure = fmt.Errorf("count %d for N=%d does not match OEIS A000170 (%d)", result.Solutions, *n, expected)
+		}
+		if *unique {
+			result.ExpectedUnique = expectedUnique
+			if result.Unique != expectedUnique {
+				result.Verified = false
+				failure = fmt.Errorf("unique count %d for N=%d does not match OEIS A002562 (%d)", result.Unique, *n, expectedUnique)
+			}
+		}
+	}
+
+	if common.format == "json" {
+		if err := writeJSON(os.Stdout, result); err != nil {
+			return err
+		}
+		return failure
+	}
+
+	if result.TimedOut {
+		return failure
+	}
+	fmt.Printf("Solutions for N=%d: %d (Time: %v)\n", *n, result.Solutions, time.Duration(result.DurationNS))
+	if *unique {
+		fmt.Printf("Fundamental solutions for N=%d: %d\n", *n, result.Unique)
+	}
+	if result.Verified {
+		if *unique {
+			fmt.Println("Matches OEIS A000170 and A002562")
+		} else {
+			fmt.Println("Matches OEIS A000170")
+		}
+	}
+	for _, board := range result.Fundamental {
+		fmt.Println(board)
+	}
+	return failure
+}
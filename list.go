@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"nqueen/nqueens"
+)
+
+// runList streams solutions to standard output as the DFS finds them
+func runList(args []string) error {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	n := fs.Int("n", 8, "board size")
+	limit := fs.Int("limit", 0, "stop after this many solutions (0 lists all)")
+	common := addCommonFlags(fs)
+	fs.Parse(args)
+
+	if err := common.setup(); err != nil {
+		return err
+	}
+	if *n < 1 {
+		return fmt.Errorf("invalid board size %d", *n)
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	var timeout <-chan time.Time
+	if common.timeout > 0 {
+		timeout = time.After(common.timeout)
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	enc := json.NewEncoder(out)
+
+	solver := nqueens.NewExhaustiveSearchSolver(*n)
+	solutions := solver.Solutions(done, *limit)
+	for {
+		select {
+		case board, ok := <-solutions:
+			if !ok {
+				return nil
+			}
+			if common.format == "json" {
+				if err := enc.Encode(board); err != nil {
+					return err
+				}
+			} else {
+				fmt.Fprintln(out, board)
+			}
+		case <-timeout:
+			out.Flush()
+			return fmt.Errorf("listing solutions for N=%d timed out after %v", *n, common.timeout)
+		}
+	}
+}
@@ -2,84 +2,59 @@ package main
 
 import (
 	"fmt"
-	"runtime"
+	"os"
 	"strings"
-	"time"
 )
 
-func main() {
-	runBasicComparison() // Quick comparison with smaller N values
-}
-
-func runBasicComparison() {
-	fmt.Println("N-Queens Problem Solver - Basic Comparison")
-	fmt.Println("==========================================")
-
-	testSizes := []int{10, 15, 20, 30, 50, 100, 200}
-	//testSizes := []int{5, 10, 15, 20, 25}
+const usage = `N-Queens Problem Solver
 
-	for _, n := range testSizes {
-		fmt.Printf("\nTesting N = %d\n", n)
-		fmt.Println(strings.Repeat("-", 50))
+Usage:
+  nqueens <command> [flags]
 
-		// Test Exhaustive Search (only for small N)
-		if n <= 20 {
-			testAlgorithmWithSolution("Exhaustive DFS", n, func() (bool, func()) {
-				solver := NewExhaustiveSearchSolver(n)
-				success := solver.Solve()
-				return success, func() { solver.PrintSolution() }
-			})
-		} else {
-			fmt.Printf("%-20s: Time: %12s, Memory: %8s, Success: %s\n",
-				"Exhaustive DFS", "SKIPPED", "N/A", "N/A (too large)")
-		}
+Commands:
+  solve     Solve one board size with one algorithm
+  compare   Compare algorithms across board sizes (default command)
+  count     Count every solution with the exhaustive solver
+  list      Stream solutions as the exhaustive solver finds them
+  help      Show this help
 
-		// Test Greedy Search
-		if n <= 50 {
-			testAlgorithmWithSolution("Greedy Hill Climbing", n, func() (bool, func()) {
-				solver := NewGreedySolver(n)
-				success := solver.Solve()
-				return success, func() { solver.PrintSolution() }
-			})
-		} else {
-			fmt.Printf("%-20s: Time: %12s, Memory: %8s, Success: %s\n",
-				"Greedy Hill Climbing", "SKIPPED", "N/A", "N/A (too large)")
-		}
+Run "nqueens <command> -h" to list the flags of a command.
+`
 
-		// Test Simulated Annealing
-		testAlgorithmWithSolution("Simulated Annealing", n, func() (bool, func()) {
-			solver := NewSimulatedAnnealingSolver(n)
-			success := solver.Solve()
-			return success, func() { solver.PrintSolution() }
-		})
-
-		// Test Genetic Algorithm
-		testAlgorithmWithSolution("Genetic Algorithm", n, func() (bool, func()) {
-			solver := NewGeneticSolver(n)
-			success := solver.Solve()
-			return success, func() { solver.PrintSolution() }
-		})
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "nqueens:", err)
+		os.Exit(1)
 	}
 }
 
-func testAlgorithmWithSolution(name string, n int, solveFunc func() (bool, func())) {
-	var m1, m2 runtime.MemStats
-	runtime.GC()
-	runtime.ReadMemStats(&m1)
-
-	start := time.Now()
-	success, printFunc := solveFunc()
-	duration := time.Since(start)
-
-	runtime.ReadMemStats(&m2)
-	memUsed := m2.TotalAlloc - m1.TotalAlloc
-	heapUsed := m2.HeapAlloc - m1.HeapAlloc
-
-	fmt.Printf("%-20s: Time: %12v, Memory: %8d KB (Heap: %d KB), Success: %v\n",
-		name, duration, memUsed/1024, heapUsed/1024, success)
+// run dispatches the command line to a subcommand
+func run(args []string) error {
+	// Without a subcommand keep the original behaviour: a basic comparison
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") && !isHelpFlag(args[0]) {
+		return runCompare(args)
+	}
 
-	// Show solution for small N values
-	if n <= 20 && success {
-		printFunc()
+	switch args[0] {
+	case "solve":
+		return runSolve(args[1:])
+	case "compare":
+		return runCompare(args[1:])
+	case "count":
+		return runCount(args[1:])
+	case "list":
+		return runList(args[1:])
+	case "help":
+		fmt.Print(usage)
+		return nil
 	}
+	if isHelpFlag(args[0]) {
+		fmt.Print(usage)
+		return nil
+	}
+	return fmt.Errorf("unknown command %q (run \"nqueens help\" for usage)", args[0])
+}
+
+func isHelpFlag(arg string) bool {
+	return arg == "-h" || arg == "-help" || arg == "--help"
 }
@@ -0,0 +1,121 @@
+package nqueens
+
+import (
+	"errors"
+	"fmt"
+)
+
+// AnnealingConfig holds every parameter of simulated annealing. Start from
+// DefaultAnnealingConfig and change the fields of interest, since a zero
+// field is invalid rather than a default.
+type AnnealingConfig struct {
+	InitialTemp   float64     `json:"initial_temp"`   // Temperature at the start of each run, unless calibrated
+	CoolingRate   float64     `json:"cooling_rate"`   // Geometric cooling factor, in (0, 1]
+	MinTemp       float64     `json:"min_temp"`       // A run stops once the temperature is this low
+	MaxIterations int         `json:"max_iterations"` // Steps per run
+	Restarts      int         `json:"restarts"`       // Runs per search, the first included
+	Schedule      string      `json:"schedule"`       // Cooling schedule name, see CoolingScheduleNames
+	Mix           NeighborMix `json:"neighbor_mix"`   // Weights of the neighbor strategies
+
+	// TargetAcceptance turns on temperature calibration when positive:
+	// each run starts at the temperature at which this share of sampled
+	// uphill moves from its starting board would be accepted, in place of
+	// InitialTemp. 0.8 is a common choice.
+	TargetAcceptance float64 `json:"target_acceptance,omitempty"`
+}
+
+// NeighborMix weights the three ways simulated annealing proposes a
+// neighbor. The weights are relative; only their ratios matter.
+type NeighborMix struct {
+	Swap       float64 `json:"swap"`       // Swap two random queens
+	Conflicted float64 `json:"conflicted"` // Move a conflicted queen to its least attacked row
+	Local      float64 `json:"local"`      // Move a random queen to a better row, if any
+}
+
+// DefaultNeighborMix is the strategy mix used unless configured otherwise
+var DefaultNeighborMix = NeighborMix{Swap: 0.6, Conflicted: 0.2, Local: 0.2}
+
+// DefaultAnnealingConfig returns the default parameters for an N×N board.
+// They are valid for every N, the empty board included.
+func DefaultAnnealingConfig(n int) AnnealingConfig {
+	n = max(n, 1)
+	return AnnealingConfig{
+		InitialTemp:   float64(n * n), // Scale with problem size
+		CoolingRate:   0.99,           // Slower cooling for better exploration
+		MinTemp:       0.01,
+		MaxIterations: n * 1000, // Scale iterations with problem size
+		Restarts:      5,        // Multiple restarts for better success rate
+		Schedule:      DefaultCoolingSchedule,
+		Mix:           DefaultNeighborMix,
+	}
+}
+
+// Validate reports the first invalid parameter of c
+func (c AnnealingConfig) Validate() error {
+	switch {
+	case !(c.MinTemp > 0):
+		return fmt.Errorf("minimum temperature %g must be positive", c.MinTemp)
+	case !(c.InitialTemp > c.MinTemp):
+		return fmt.Errorf("initial temperature %g must exceed the minimum temperature %g", c.InitialTemp, c.MinTemp)
+	case !(c.CoolingRate > 0 && c.CoolingRate <= 1):
+		return fmt.Errorf("cooling rate %g must be in (0, 1]", c.CoolingRate)
+	case c.MaxIterations < 1:
+		return fmt.Errorf("maximum iterations %d must be at least 1", c.MaxIterations)
+	case c.Restarts < 1:
+		return fmt.Errorf("restarts %d must be at least 1", c.Restarts)
+	case !(c.TargetAcceptance >= 0 && c.TargetAcceptance < 1):
+		return fmt.Errorf("target acceptance %g must be in [0, 1)", c.TargetAcceptance)
+	}
+	if _, err := NewCoolingSchedule(c.Schedule); err != nil {
+		return err
+	}
+	return c.Mix.validate()
+}
+
+func (m NeighborMix) validate() error {
+	if m.Swap < 0 || m.Conflicted < 0 || m.Local < 0 {
+		return fmt.Errorf("neighbor mix %v has a negative weight", m)
+	}
+	if !(m.Swap+m.Conflicted+m.Local > 0) {
+		return errors.New("neighbor mix weights must not all be zero")
+	}
+	return nil
+}
+
+// thresholds turns the weights into the cumulative probabilities below
+// which a uniform draw picks a swap or a conflicted move
+func (m NeighborMix) thresholds() (swap, conflicted float64) {
+	total := m.Swap + m.Conflicted + m.Local
+	return m.Swap / total, (m.Swap + m.Conflicted) / total
+}
+
+// AnnealingConfig returns the simulated annealing parameters for an N×N
+// board: the defaults, overridden by the non-zero options
+func (o Options) AnnealingConfig(n int) AnnealingConfig {
+	c := DefaultAnnealingConfig(n)
+	if o.InitialTemp > 0 {
+		c.InitialTemp = o.InitialTemp
+	}
+	if o.CoolingRate > 0 {
+		c.CoolingRate = o.CoolingRate
+	}
+	if o.MinTemp > 0 {
+		c.MinTemp = o.MinTemp
+	}
+	if o.MaxIterations > 0 {
+		c.MaxIterations = o.MaxIterations
+	}
+	if o.Restarts > 0 {
+		c.Restarts = o.Restarts
+	}
+	if o.Schedule != "" {
+		c.Schedule = o.Schedule
+	}
+	if o.NeighborMix != nil {
+		c.Mix = *o.NeighborMix
+	}
+	if o.TargetAcceptance > 0 {
+		c.TargetAcceptance = o.TargetAcceptance
+	}
+	return c
+}
@@ -0,0 +1,61 @@
+package nqueens_test
+
+import (
+	"fmt"
+	"testing"
+
+	"nqueen/nqueens"
+)
+
+// benchmarkSizes are the board sizes each algorithm is benchmarked on,
+// kept within the sizes it solves in well under a second
+var benchmarkSizes = map[string][]int{
+	"dfs":       {8, 12, 20},
+	"greedy":    {8, 12},
+	"sa":        {8, 20, 50},
+	"ga":        {8, 10},
+	"mc":        {8, 100, 1000, 10000},
+	"pt":        {8, 50, 100},
+	"tabu":      {8, 50, 100},
+	"construct": {8, 1000, 100000},
+}
+
+// BenchmarkSolve runs every algorithm on its benchmark sizes. Sub-benchmarks
+// are named algorithm/N=n, so results compare across commits with
+// benchstat. Each iteration uses its own seed, so the timings average over
+// searches rather than repeating one lucky or unlucky run. The search
+// iterations per solve are reported alongside the time.
+func BenchmarkSolve(b *testing.B) {
+	for _, algo := range nqueens.Algorithms() {
+		for _, n := range benchmarkSizes[algo.Name] {
+			b.Run(fmt.Sprintf("%s/N=%d", algo.Name, n), func(b *testing.B) {
+				b.ReportAllocs()
+				var iterations int64
+				for i := 0; i < b.N; i++ {
+					solver := algo.New(n, nqueens.Options{Seed: int64(i + 1)})
+					solver.Solve()
+					iterations += solver.Stats().Iterations
+				}
+				b.ReportMetric(float64(iterations)/float64(b.N), "iterations/op")
+			})
+		}
+	}
+}
+
+func BenchmarkCountSolutions(b *testing.B) {
+	for _, n := range []int{8, 10, 12} {
+		b.Run(fmt.Sprintf("N=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				nqueens.NewExhaustiveSearchSolver(n).CountSolutions()
+			}
+		})
+	}
+}
+
+func BenchmarkValidate(b *testing.B) {
+	board := nqueens.Construct(100000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		nqueens.Validate(board)
+	}
+}
@@ -0,0 +1,275 @@
+package nqueens
+
+import "math/bits"
+
+// placementSearch enumerates queen placements row by row, tracking the
+// occupied columns and both diagonals as bitmasks so that the free squares
+// of a row are found with a few word operations instead of a scan of the
+// earlier rows.
+type placementSearch interface {
+	// run explores every completion of prefix (the column of the queen in
+	// each of the first rows), calling visit for each solution until it
+	// returns false or done is closed. It returns the number of solutions
+	// found.
+	run(done <-chan struct{}, prefix []int, visit func(board []int) bool) int64
+	// interrupted reports whether the last run was stopped by done
+	interrupted() bool
+	// deepest returns the longest conflict-free placement reached by the
+	// last run
+	deepest() []int
+	// counters returns the number of nodes expanded by the last run and
+	// how many of them had no solution below them
+	counters() (nodes, backtracks int64)
+}
+
+// pollInterval is the number of nodes expanded between checks of the done
+// channel; it must be a power of two
+const pollInterval = 1 << 12
+
+// newPlacementSearch picks the single-word engine when the board fits in a
+// machine word and the multiword engine otherwise
+func newPlacementSearch(n int) placementSearch {
+	if n <= 64 {
+		return newBitSearch(n)
+	}
+	return newWideSearch(n)
+}
+
+// bitSearch is the backtracking engine for N ≤ 64
+type bitSearch struct {
+	n          int
+	full       uint64 // One bit per column
+	board      []int
+	best       []int // Deepest placement reached
+	depth      int
+	nodes      int64
+	backtracks int64
+	done       <-chan struct{}
+	visit      func(board []int) bool
+	stopped    bool
+	canceled   bool
+}
+
+func newBitSearch(n int) *bitSearch {
+	full := ^uint64(0)
+	if n < 64 {
+		full = uint64(1)<<uint(n) - 1
+	}
+	return &bitSearch{
+		n:     n,
+		full:  full,
+		board: make([]int, n),
+		best:  make([]int, n),
+	}
+}
+
+func (b *bitSearch) run(done <-chan struct{}, prefix []int, visit func(board []int) bool) int64 {
+	b.done = done
+	b.visit = visit
+	b.stopped = false
+	b.canceled = false
+	b.depth = 0
+	b.nodes = 0
+	b.backtracks = 0
+
+	// Replay the prefix, giving up if it already contains an attack
+	var cols, diag, anti uint64
+	for row, col := range prefix {
+		bit := uint64(1) << uint(col)
+		if (cols|diag|anti)&bit != 0 {
+			return 0
+		}
+		b.board[row] = col
+		cols |= bit
+		diag = (diag | bit) << 1
+		anti = (anti | bit) >> 1
+	}
+	return b.search(len(prefix), cols, diag, anti)
+}
+
+// search counts the completions below row. diag and anti hold the squares
+// of this row attacked along the two diagonals by the queens above it.
+func (b *bitSearch) search(row int, cols, diag, anti uint64) int64 {
+	if row > b.depth {
+		b.depth = row
+		copy(b.best, b.board[:row])
+	}
+	b.nodes++
+	if b.nodes&(pollInterval-1) == 0 && isDone(b.done) {
+		b.stopped = true
+		b.canceled = true
+		return 0
+	}
+
+	if row == b.n {
+		if b.visit != nil && !b.visit(b.board) {
+			b.stopped = true
+		}
+		return 1
+	}
+
+	var count int64
+	avail := b.full &^ (cols | diag | anti)
+	for avail != 0 && !b.stopped {
+		// Take the lowest free column first
+		bit := avail & -avail
+		avail ^= bit
+		b.board[row] = bits.TrailingZeros64(bit)
+		count += b.search(row+1, cols|bit, (diag|bit)<<1, (anti|bit)>>1)
+	}
+	if count == 0 {
+		b.backtracks++
+	}
+	return count
+}
+
+func (b *bitSearch) interrupted() bool {
+	return b.canceled
+}
+
+func (b *bitSearch) deepest() []int {
+	return b.best[:b.depth]
+}
+
+func (b *bitSearch) counters() (nodes, backtracks int64) {
+	return b.nodes, b.backtracks
+}
+
+// wideSearch is the multiword backtracking engine for N > 64. The masks of
+// every row are preallocated so the search itself does not allocate.
+type wideSearch struct {
+	n          int
+	words      int
+	full       []uint64
+	cols       [][]uint64 // Masks per row, index row holds the state on entry
+	diag       [][]uint64
+	anti       [][]uint64
+	board      []int
+	best       []int // Deepest placement reached
+	depth      int
+	nodes      int64
+	backtracks int64
+	done       <-chan struct{}
+	visit      func(board []int) bool
+	stopped    bool
+	canceled   bool
+}
+
+func newWideSearch(n int) *wideSearch {
+	words := (n + 63) / 64
+	w := &wideSearch{
+		n:     n,
+		words: words,
+		full:  make([]uint64, words),
+		cols:  make([][]uint64, n+1),
+		diag:  make([][]uint64, n+1),
+		anti:  make([][]uint64, n+1),
+		board: make([]int, n),
+		best:  make([]int, n),
+	}
+	for col := 0; col < n; col++ {
+		w.full[col/64] |= uint64(1) << uint(col%64)
+	}
+	for row := 0; row <= n; row++ {
+		w.cols[row] = make([]uint64, words)
+		w.diag[row] = make([]uint64, words)
+		w.anti[row] = make([]uint64, words)
+	}
+	return w
+}
+
+func (w *wideSearch) run(done <-chan struct{}, prefix []int, visit func(board []int) bool) int64 {
+	w.done = done
+	w.visit = visit
+	w.stopped = false
+	w.canceled = false
+	w.depth = 0
+	w.nodes = 0
+	w.backtracks = 0
+
+	for i := 0; i < w.words; i++ {
+		w.cols[0][i], w.diag[0][i], w.anti[0][i] = 0, 0, 0
+	}
+	for row, col := range prefix {
+		word, bit := col/64, uint64(1)<<uint(col%64)
+		if (w.cols[row][word]|w.diag[row][word]|w.anti[row][word])&bit != 0 {
+			return 0
+		}
+		w.board[row] = col
+		w.place(row, word, bit)
+	}
+	return w.search(len(prefix))
+}
+
+// place fills in the masks of row+1 after a queen is put on (row, bit)
+func (w *wideSearch) place(row, word int, bit uint64) {
+	cols, diag, anti := w.cols[row+1], w.diag[row+1], w.anti[row+1]
+	copy(cols, w.cols[row])
+	cols[word] |= bit
+
+	// (diag | bit) << 1 across words, high word first
+	copy(diag, w.diag[row])
+	diag[word] |= bit
+	for i := w.words - 1; i > 0; i-- {
+		diag[i] = diag[i]<<1 | diag[i-1]>>63
+	}
+	diag[0] <<= 1
+
+	// (anti | bit) >> 1 across words, low word first
+	copy(anti, w.anti[row])
+	anti[word] |= bit
+	for i := 0; i < w.words-1; i++ {
+		anti[i] = anti[i]>>1 | anti[i+1]<<63
+	}
+	anti[w.words-1] >>= 1
+}
+
+// search counts the completions below row
+func (w *wideSearch) search(row int) int64 {
+	if row > w.depth {
+		w.depth = row
+		copy(w.best, w.board[:row])
+	}
+	w.nodes++
+	if w.nodes&(pollInterval-1) == 0 && isDone(w.done) {
+		w.stopped = true
+		w.canceled = true
+		return 0
+	}
+
+	if row == w.n {
+		if w.visit != nil && !w.visit(w.board) {
+			w.stopped = true
+		}
+		return 1
+	}
+
+	var count int64
+	cols, diag, anti := w.cols[row], w.diag[row], w.anti[row]
+	for word := 0; word < w.words; word++ {
+		avail := w.full[word] &^ (cols[word] | diag[word] | anti[word])
+		for avail != 0 && !w.stopped {
+			bit := avail & -avail
+			avail ^= bit
+			w.board[row] = word*64 + bits.TrailingZeros64(bit)
+			w.place(row, word, bit)
+			count += w.search(row + 1)
+		}
+	}
+	if count == 0 {
+		w.backtracks++
+	}
+	return count
+}
+
+func (w *wideSearch) interrupted() bool {
+	return w.canceled
+}
+
+func (w *wideSearch) deepest() []int {
+	return w.best[:w.depth]
+}
+
+func (w *wideSearch) counters() (nodes, backtracks int64) {
+	return w.nodes, w.backtracks
+}
@@ -0,0 +1,109 @@
+package nqueens
+
+import (
+	"fmt"
+	"testing"
+)
+
+// explicitSolution returns the classic closed-form solution for boards
+// with N mod 6 not 2 or 3: the odd columns first, then the even ones
+func explicitSolution(n int) []int {
+	board := make([]int, 0, n)
+	even := n - n%2
+	for col := 1; col < even; col += 2 {
+		board = append(board, col)
+	}
+	for col := 0; col < even; col += 2 {
+		board = append(board, col)
+	}
+	if n%2 == 1 {
+		board = append(board, n-1)
+	}
+	return board
+}
+
+func TestPlacementSearchEngine(t *testing.T) {
+	if _, ok := newPlacementSearch(64).(*bitSearch); !ok {
+		t.Error("N=64 does not use the single-word engine")
+	}
+	if _, ok := newPlacementSearch(65).(*wideSearch); !ok {
+		t.Error("N=65 does not use the multiword engine")
+	}
+}
+
+func TestWideSearchMatchesKnownCounts(t *testing.T) {
+	// The multiword engine runs on any N, so its counts are checked on the
+	// boards small enough to enumerate
+	maxN := 12
+	if testing.Short() {
+		maxN = 10
+	}
+	for n := 1; n <= maxN; n++ {
+		want, _ := KnownSolutionCount(n)
+		var invalid int
+		got := newWideSearch(n).run(nil, nil, func(board []int) bool {
+			if CountConflicts(board) != 0 {
+				invalid++
+			}
+			return true
+		})
+		if got != want {
+			t.Errorf("N=%d: counted %d solutions, want %d", n, got, want)
+		}
+		if invalid > 0 {
+			t.Errorf("N=%d: visited %d invalid boards", n, invalid)
+		}
+	}
+}
+
+func TestWideSearchCompletesLargeBoards(t *testing.T) {
+	// Fixing all but the last rows to a known solution leaves a small
+	// search whose diagonals still cross word boundaries
+	for _, n := range []int{65, 100, 130, 199} {
+		t.Run(fmt.Sprintf("N=%d", n), func(t *testing.T) {
+			prefix := explicitSolution(n)[:n-12]
+			if conflicts := CountConflicts(explicitSolution(n)); conflicts != 0 {
+				t.Fatalf("explicit solution has %d conflicts", conflicts)
+			}
+			var visited int64
+			count := newWideSearch(n).run(nil, prefix, func(board []int) bool {
+				visited++
+				if conflicts := CountConflicts(board); conflicts != 0 {
+					t.Fatalf("solution %v has %d conflicts", board, conflicts)
+				}
+				for row, col := range prefix {
+					if board[row] != col {
+						t.Fatalf("row %d moved from %d to %d", row, col, board[row])
+					}
+				}
+				return true
+			})
+			if count < 1 || count != visited {
+				t.Errorf("counted %d completions, visited %d", count, visited)
+			}
+
+			first := newWideSearch(n).run(nil, prefix, func([]int) bool { return false })
+			if first != 1 {
+				t.Errorf("stopping at the first solution counted %d", first)
+			}
+		})
+	}
+}
+
+func TestWideSearchRejectsConflictingPrefix(t *testing.T) {
+	// A queen 68 rows below the first of a known solution, on its
+	// diagonal, 68 columns over in the next word
+	far := explicitSolution(136)[:68:68]
+	far = append(far, far[0]+68)
+
+	for _, prefix := range [][]int{
+		{66, 66}, // Same column in the second word
+		{63, 64}, // Diagonal across the word boundary
+		{64, 63}, // Anti-diagonal across the word boundary
+		far,
+	} {
+		if got := newWideSearch(136).run(nil, prefix, nil); got != 0 {
+			t.Errorf("prefix %v: counted %d solutions", prefix, got)
+		}
+	}
+}
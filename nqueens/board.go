@@ -0,0 +1,86 @@
+package nqueens
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// CountConflicts returns the number of attacking queen pairs on a board.
+// The board holds one queen per index; two queens conflict when they share
+// a value (same line) or sit on the same diagonal.
+func CountConflicts(board []int) int {
+	conflicts := 0
+	for i := 0; i < len(board); i++ {
+		for j := i + 1; j < len(board); j++ {
+			// Check row conflict
+			if board[i] == board[j] {
+				conflicts++
+			}
+			// Check diagonal conflict
+			if abs(board[i]-board[j]) == abs(i-j) {
+				conflicts++
+			}
+		}
+	}
+	return conflicts
+}
+
+// ConflictsAt returns the number of queens attacking the queen at index col
+func ConflictsAt(board []int, col int) int {
+	conflicts := 0
+	for j := 0; j < len(board); j++ {
+		if j != col {
+			// Check row conflict
+			if board[col] == board[j] {
+				conflicts++
+			}
+			// Check diagonal conflict
+			if abs(board[col]-board[j]) == abs(col-j) {
+				conflicts++
+			}
+		}
+	}
+	return conflicts
+}
+
+// FormatBoard renders a board as an ASCII grid, one line per index
+func FormatBoard(board []int) string {
+	var sb strings.Builder
+	for i := 0; i < len(board); i++ {
+		for j := 0; j < len(board); j++ {
+			if board[i] == j {
+				sb.WriteString("Q ")
+			} else {
+				sb.WriteString(". ")
+			}
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// FprintBoard writes a titled board to w, or "No solution found" for a nil board
+func FprintBoard(w io.Writer, title string, board []int) {
+	if board == nil {
+		fmt.Fprintln(w, "No solution found")
+		return
+	}
+
+	fmt.Fprintf(w, "%s for N=%d:\n", title, len(board))
+	fmt.Fprint(w, FormatBoard(board))
+	fmt.Fprintln(w)
+}
+
+// printBoard writes a titled board to standard output
+func printBoard(title string, board []int) {
+	FprintBoard(os.Stdout, title, board)
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
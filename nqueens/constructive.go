@@ -0,0 +1,114 @@
+package nqueens
+
+import (
+	"context"
+	"fmt"
+)
+
+// ConstructiveSolver places the queens with the explicit construction for
+// N ≥ 4 instead of searching. Counting from 1, the queens take the even
+// values followed by the odd ones, with two fix-ups that keep the
+// diagonals free when N mod 6 is 2 or 3. The board is built in O(N), and
+// there is no solution to find for N = 2 and N = 3.
+type ConstructiveSolver struct {
+	n        int
+	solution []int
+	solved   bool
+}
+
+// NewConstructiveSolver creates a new constructive solver
+func NewConstructiveSolver(n int) *ConstructiveSolver {
+	return &ConstructiveSolver{n: n}
+}
+
+// Solve builds the placement, reporting false when N has no solution
+func (c *ConstructiveSolver) Solve() bool {
+	solved, _ := c.SolveContext(context.Background())
+	return solved
+}
+
+// SolveContext is Solve bounded by ctx. The construction does not search,
+// so ctx is only checked before it starts.
+func (c *ConstructiveSolver) SolveContext(ctx context.Context) (bool, error) {
+	c.solution = nil
+	c.solved = false
+	if isDone(ctx.Done()) {
+		return false, canceledError(ctx)
+	}
+
+	c.solution = Construct(c.n)
+	c.solved = c.solution != nil
+	return c.solved, nil
+}
+
+// Construct returns a valid placement of n queens, or nil when there is
+// none (N = 2, 3 and N < 1). Values are listed 1-based as in the usual
+// statement of the construction and shifted to 0-based on output:
+//
+//	N mod 6 not 2 or 3: 2, 4, …, then 1, 3, 5, …
+//	N mod 6 = 2:        2, 4, …, then 3, 1, 7, 9, …, 5
+//	N mod 6 = 3:        4, 6, …, 2, then 5, 7, …, 1, 3
+func Construct(n int) []int {
+	if n < 1 || n == 2 || n == 3 {
+		return nil
+	}
+
+	board := make([]int, 0, n)
+	add := func(value int) { board = append(board, value-1) }
+
+	switch n % 6 {
+	case 2:
+		for v := 2; v <= n; v += 2 {
+			add(v)
+		}
+		add(3)
+		add(1)
+		for v := 7; v <= n; v += 2 {
+			add(v)
+		}
+		add(5)
+	case 3:
+		for v := 4; v <= n; v += 2 {
+			add(v)
+		}
+		add(2)
+		for v := 5; v <= n; v += 2 {
+			add(v)
+		}
+		add(1)
+		add(3)
+	default:
+		for v := 2; v <= n; v += 2 {
+			add(v)
+		}
+		for v := 1; v <= n; v += 2 {
+			add(v)
+		}
+	}
+	return board
+}
+
+// Best returns the solution, or nil when N has none or before Solve
+func (c *ConstructiveSolver) Best() ([]int, int) {
+	return c.solution, 0
+}
+
+// Stats returns empty statistics, since the construction does not search
+func (c *ConstructiveSolver) Stats() SearchStats {
+	return SearchStats{}
+}
+
+// GetSolution returns the found solution
+func (c *ConstructiveSolver) GetSolution() []int {
+	return c.solution
+}
+
+// PrintSolution prints the solution board
+func (c *ConstructiveSolver) PrintSolution() {
+	if !c.solved {
+		fmt.Println("No solution found")
+		return
+	}
+
+	printBoard("Constructive Solution", c.solution)
+}
@@ -0,0 +1,62 @@
+package nqueens_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"nqueen/nqueens"
+)
+
+func TestSolveContextCanceled(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+
+	// Seeded, since min-conflicts may place a solution before its first
+	// check of the context
+	for _, algo := range nqueens.Algorithms() {
+		for _, ctx := range []context.Context{canceled, expired} {
+			solver := algo.New(30, nqueens.Options{Seed: testSeed})
+			solved, err := solver.SolveContext(ctx)
+			if solved {
+				t.Errorf("%s: solved despite %v", algo.Name, ctx.Err())
+			}
+			if !errors.Is(err, nqueens.ErrCanceled) || !errors.Is(err, ctx.Err()) {
+				t.Errorf("%s: error %v does not wrap ErrCanceled and %v", algo.Name, err, ctx.Err())
+			}
+			if algo.Name == "construct" {
+				continue // Nothing is built before the context is checked
+			}
+			if best, _ := solver.Best(); len(best) == 0 {
+				t.Errorf("%s: no best board after %v", algo.Name, ctx.Err())
+			}
+		}
+	}
+}
+
+func TestExhaustiveBestAfterCancel(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	solver := nqueens.NewExhaustiveSearchSolver(30)
+	if _, err := solver.SolveContext(canceled); !errors.Is(err, nqueens.ErrCanceled) {
+		t.Fatalf("SolveContext returned %v", err)
+	}
+
+	// The unfinished search still reports a full board and its real
+	// number of conflicts
+	board, conflicts := solver.Best()
+	if len(board) != 30 {
+		t.Fatalf("Best() has %d queens, want 30", len(board))
+	}
+	for row, col := range board {
+		if col < 0 || col >= 30 {
+			t.Errorf("row %d: column %d off the board", row, col)
+		}
+	}
+	if want := nqueens.CountConflicts(board); conflicts != want || conflicts == 0 {
+		t.Errorf("Best() reports %d conflicts, the board has %d", conflicts, want)
+	}
+}
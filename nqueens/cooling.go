@@ -0,0 +1,180 @@
+package nqueens
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// CoolingSchedule sets the temperature of each simulated annealing step.
+// A schedule keeps per-run state, so a solver owns its schedule and calls
+// Start at the beginning of every run.
+type CoolingSchedule interface {
+	// Start resets the schedule for a run and returns its first temperature
+	Start(p CoolingParams) float64
+	// Next returns the temperature after step s. When reheat is true the
+	// search resumes from the best board of the run at that temperature.
+	Next(s CoolingStep) (temperature float64, reheat bool)
+}
+
+// CoolingParams are the solver settings a schedule is started with
+type CoolingParams struct {
+	Initial       float64 // Starting temperature
+	Min           float64 // The run stops once the temperature is this low
+	Rate          float64 // Geometric cooling factor per iteration
+	MaxIterations int     // Iteration budget of the run
+}
+
+// CoolingStep describes the iteration that just ended
+type CoolingStep struct {
+	Iteration   int // Iteration within the run, from 0
+	Temperature float64
+	Accepted    bool // Whether the neighbor was accepted
+	Cost        int  // Conflicts on the current board
+	BestCost    int  // Fewest conflicts seen in the run
+}
+
+var coolingSchedules = map[string]func() CoolingSchedule{
+	"geometric":  func() CoolingSchedule { return &geometricCooling{} },
+	"linear":     func() CoolingSchedule { return &linearCooling{} },
+	"log":        func() CoolingSchedule { return &logCooling{} },
+	"lundy-mees": func() CoolingSchedule { return &lundyMeesCooling{} },
+	"adaptive":   func() CoolingSchedule { return &adaptiveCooling{} },
+	"reheat":     func() CoolingSchedule { return &reheatCooling{} },
+}
+
+// DefaultCoolingSchedule is the schedule used when none is selected
+const DefaultCoolingSchedule = "reheat"
+
+// NewCoolingSchedule returns a new instance of the schedule registered
+// under name
+func NewCoolingSchedule(name string) (CoolingSchedule, error) {
+	newSchedule, ok := coolingSchedules[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown cooling schedule %q (available: %v)", name, CoolingScheduleNames())
+	}
+	return newSchedule(), nil
+}
+
+// CoolingScheduleNames returns the sorted names of the cooling schedules
+func CoolingScheduleNames() []string {
+	names := make([]string, 0, len(coolingSchedules))
+	for name := range coolingSchedules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// geometricCooling multiplies the temperature by the rate every iteration
+type geometricCooling struct {
+	rate float64
+}
+
+func (c *geometricCooling) Start(p CoolingParams) float64 {
+	c.rate = p.Rate
+	return p.Initial
+}
+
+func (c *geometricCooling) Next(s CoolingStep) (float64, bool) {
+	return s.Temperature * c.rate, false
+}
+
+// linearCooling lowers the temperature by a fixed step, reaching the
+// minimum at the end of the iteration budget
+type linearCooling struct {
+	step float64
+}
+
+func (c *linearCooling) Start(p CoolingParams) float64 {
+	c.step = (p.Initial - p.Min) / float64(max(p.MaxIterations, 1))
+	return p.Initial
+}
+
+func (c *linearCooling) Next(s CoolingStep) (float64, bool) {
+	return s.Temperature - c.step, false
+}
+
+// logCooling is the classic T0 / ln(e + k) schedule, which cools too
+// slowly to finish within a practical budget but converges in theory
+type logCooling struct {
+	initial float64
+}
+
+func (c *logCooling) Start(p CoolingParams) float64 {
+	c.initial = p.Initial
+	return p.Initial
+}
+
+func (c *logCooling) Next(s CoolingStep) (float64, bool) {
+	return c.initial / math.Log(math.E+float64(s.Iteration+1)), false
+}
+
+// lundyMeesCooling applies T / (1 + βT) every iteration, with β chosen so
+// that the minimum is reached at the end of the iteration budget
+type lundyMeesCooling struct {
+	beta float64
+}
+
+func (c *lundyMeesCooling) Start(p CoolingParams) float64 {
+	c.beta = (p.Initial - p.Min) / (float64(max(p.MaxIterations, 1)) * p.Initial * p.Min)
+	return p.Initial
+}
+
+func (c *lundyMeesCooling) Next(s CoolingStep) (float64, bool) {
+	return s.Temperature / (1 + c.beta*s.Temperature), false
+}
+
+// adaptiveWindow is the number of iterations over which the adaptive
+// schedule measures the acceptance ratio
+const adaptiveWindow = 100
+
+// adaptiveCooling steers the acceptance ratio toward a target that falls
+// linearly from 50% to 0 over the iteration budget. After every window the
+// temperature is scaled by exp(2(target - ratio)): cooled while too many
+// moves are accepted, warmed while too few are.
+type adaptiveCooling struct {
+	budget   int
+	accepted int
+}
+
+func (c *adaptiveCooling) Start(p CoolingParams) float64 {
+	c.budget = max(p.MaxIterations, 1)
+	c.accepted = 0
+	return p.Initial
+}
+
+func (c *adaptiveCooling) Next(s CoolingStep) (float64, bool) {
+	if s.Accepted {
+		c.accepted++
+	}
+	if (s.Iteration+1)%adaptiveWindow != 0 {
+		return s.Temperature, false
+	}
+
+	ratio := float64(c.accepted) / adaptiveWindow
+	target := 0.5 * (1 - float64(s.Iteration+1)/float64(c.budget))
+	c.accepted = 0
+	return s.Temperature * math.Exp(2*(target-ratio)), false
+}
+
+// reheatCooling cools geometrically, but every 100 iterations it checks
+// whether the current cost has drifted to more than twice the best; if so
+// the search returns to its best board at half the initial temperature
+type reheatCooling struct {
+	rate    float64
+	initial float64
+}
+
+func (c *reheatCooling) Start(p CoolingParams) float64 {
+	c.rate = p.Rate
+	c.initial = p.Initial
+	return p.Initial
+}
+
+func (c *reheatCooling) Next(s CoolingStep) (float64, bool) {
+	if s.Iteration%100 == 0 && s.Cost > s.BestCost*2 {
+		return c.initial * 0.5, true
+	}
+	return s.Temperature * c.rate, false
+}
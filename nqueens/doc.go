@@ -0,0 +1,19 @@
+// Package nqueens provides several solvers for the N-Queens problem:
+// exhaustive depth-first search, greedy hill climbing, simulated annealing,
+// a genetic algorithm, min-conflicts repair, parallel tempering, tabu
+// search and an explicit construction.
+//
+// Every solver implements the Solver interface and is available by name
+// from the algorithm registry:
+//
+//	solver, err := nqueens.NewSolver("sa", 100, nqueens.Options{})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	if solver.Solve() {
+//		fmt.Println(solver.GetSolution())
+//	}
+//
+// Boards are represented as a slice with one queen per index, where the
+// value is the queen's position along the other axis.
+package nqueens
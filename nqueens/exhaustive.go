@@ -0,0 +1,144 @@
+package nqueens
+
+import (
+	"context"
+	"fmt"
+)
+
+// ExhaustiveSearchSolver implements depth-first search with backtracking.
+// Occupied columns and diagonals are tracked as bitmasks (see bitboard.go).
+type ExhaustiveSearchSolver struct {
+	n             int
+	search        placementSearch
+	solution      []int
+	solutionFound bool
+	countAll      bool  // Counting mode: keep searching after the first solution
+	solutionCount int64 // Solutions seen by the last search
+}
+
+// NewExhaustiveSearchSolver creates a new exhaustive search solver
+func NewExhaustiveSearchSolver(n int) *ExhaustiveSearchSolver {
+	return &ExhaustiveSearchSolver{
+		n:      n,
+		search: newPlacementSearch(n),
+	}
+}
+
+// SetCountAll switches counting mode on or off. In counting mode Solve
+// explores the whole search tree, keeping the first solution and counting
+// every solution instead of stopping at the first one.
+func (e *ExhaustiveSearchSolver) SetCountAll(countAll bool) {
+	e.countAll = countAll
+}
+
+// Solve attempts to find a solution using exhaustive depth-first search
+func (e *ExhaustiveSearchSolver) Solve() bool {
+	solved, _ := e.SolveContext(context.Background())
+	return solved
+}
+
+// SolveContext is Solve bounded by ctx. In counting mode a canceled search
+// still reports its first solution, but SolutionCount is then partial.
+func (e *ExhaustiveSearchSolver) SolveContext(ctx context.Context) (bool, error) {
+	e.solutionFound = false
+	e.solution = nil
+	e.solutionCount = e.search.run(ctx.Done(), nil, e.visit)
+	if e.search.interrupted() {
+		return e.solutionFound, canceledError(ctx)
+	}
+	return e.solutionFound, nil
+}
+
+// Best returns the solution once found. Otherwise it completes the deepest
+// placement reached so far, which has no conflicts, by putting the queen of
+// each row left empty on its least attacked square, and returns that board
+// with its number of conflicts.
+func (e *ExhaustiveSearchSolver) Best() ([]int, int) {
+	if e.solutionFound {
+		return e.solution, 0
+	}
+	board := completeBoard(e.n, e.search.deepest())
+	return board, CountConflicts(board)
+}
+
+// completeBoard extends a placement of the first rows to a full board,
+// row by row, choosing the lowest column attacked by the fewest queens
+// already placed
+func completeBoard(n int, placed []int) []int {
+	board := make([]int, n)
+	cols := make([]int, n)
+	diag := make([]int, 2*n)
+	anti := make([]int, 2*n)
+	put := func(row, col int) {
+		board[row] = col
+		cols[col]++
+		diag[row+col]++
+		anti[row-col+n]++
+	}
+	attacks := func(row, col int) int {
+		return cols[col] + diag[row+col] + anti[row-col+n]
+	}
+
+	for row, col := range placed {
+		put(row, col)
+	}
+	for row := len(placed); row < n; row++ {
+		best := 0
+		for col := 1; col < n; col++ {
+			if attacks(row, col) < attacks(row, best) {
+				best = col
+			}
+		}
+		put(row, best)
+	}
+	return board
+}
+
+// Stats returns the statistics of the last search. Each expanded node is an
+// iteration; the search keeps no cost trace, since it never places
+// attacking queens.
+func (e *ExhaustiveSearchSolver) Stats() SearchStats {
+	nodes, backtracks := e.search.counters()
+	return SearchStats{Nodes: nodes, Backtracks: backtracks, Iterations: nodes}
+}
+
+// CountSolutions runs a full search in counting mode and returns the total
+// number of solutions (OEIS A000170)
+func (e *ExhaustiveSearchSolver) CountSolutions() int64 {
+	countAll := e.countAll
+	e.countAll = true
+	e.Solve()
+	e.countAll = countAll
+	return e.solutionCount
+}
+
+// SolutionCount returns the number of solutions seen by the last search.
+// It is the full count only after a search in counting mode.
+func (e *ExhaustiveSearchSolver) SolutionCount() int64 {
+	return e.solutionCount
+}
+
+// visit records the first solution and tells the search whether to go on
+func (e *ExhaustiveSearchSolver) visit(board []int) bool {
+	if !e.solutionFound {
+		e.solution = make([]int, e.n)
+		copy(e.solution, board)
+		e.solutionFound = true
+	}
+	return e.countAll
+}
+
+// GetSolution returns the found solution
+func (e *ExhaustiveSearchSolver) GetSolution() []int {
+	return e.solution
+}
+
+// PrintSolution prints the solution board
+func (e *ExhaustiveSearchSolver) PrintSolution() {
+	if !e.solutionFound {
+		fmt.Println("No solution found")
+		return
+	}
+
+	printBoard("Exhaustive Search Solution", e.solution)
+}
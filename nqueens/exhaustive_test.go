@@ -0,0 +1,30 @@
+package nqueens_test
+
+import (
+	"testing"
+
+	"nqueen/nqueens"
+)
+
+func TestCountSolutionsMatchesKnownCounts(t *testing.T) {
+	maxN := 16
+	if testing.Short() {
+		maxN = 12
+	}
+	for n := 1; n <= maxN; n++ {
+		want, _ := nqueens.KnownSolutionCount(n)
+		solver := nqueens.NewExhaustiveSearchSolver(n)
+		// The larger boards are only counted in parallel, to save time
+		if n <= 12 {
+			if got := solver.CountSolutions(); got != want {
+				t.Errorf("CountSolutions() for N=%d = %d, want %d", n, got, want)
+			}
+			if got := solver.SolutionCount(); got != want {
+				t.Errorf("SolutionCount() for N=%d = %d after counting, want %d", n, got, want)
+			}
+		}
+		if got := solver.CountSolutionsParallel(4); got != want {
+			t.Errorf("CountSolutionsParallel(4) for N=%d = %d, want %d", n, got, want)
+		}
+	}
+}
@@ -1,8 +1,8 @@
-package main
+package nqueens
 
 import (
+	"context"
 	"fmt"
-	"math/rand"
 	"sort"
 )
 
@@ -18,11 +18,17 @@ type GeneticSolver struct {
 	populationSize int
 	maxGenerations int
 	mutationRate   float64
+	baseMutation   float64
 	crossoverRate  float64
 	population     []Individual
+	state          *boardState // Scratch counters for scoring chromosomes
 	solution       []int
 	solved         bool
+	bestSeen       // Fittest chromosome over all runs
 	restarts       int
+	stats          SearchStats
+	trace          tracer
+	seededSource
 }
 
 // NewGeneticSolver creates a new genetic algorithm solver
@@ -36,30 +42,102 @@ func NewGeneticSolver(n int) *GeneticSolver {
 		popSize = 150
 	}
 
-	return &GeneticSolver{
+	ga := &GeneticSolver{
 		n:              n,
 		populationSize: popSize,
 		maxGenerations: 200,  // More generations for better results
 		mutationRate:   0.15, // Balanced mutation rate
+		baseMutation:   0.15,
 		crossoverRate:  0.85, // Higher crossover rate
 		population:     make([]Individual, popSize),
+		state:          newBoardState(n),
 		restarts:       5, // More restarts for much better success
 	}
+	ga.SetSeed(newSeed())
+	return ga
+}
+
+// applyOptions overrides the defaults with any non-zero registry options
+func (ga *GeneticSolver) applyOptions(opts Options) {
+	if opts.Seed != 0 {
+		ga.SetSeed(opts.Seed)
+	}
+	if opts.PopulationSize > 0 {
+		ga.populationSize = opts.PopulationSize
+		ga.population = make([]Individual, opts.PopulationSize)
+	}
+	if opts.Generations > 0 {
+		ga.maxGenerations = opts.Generations
+	}
+	if opts.MutationRate > 0 {
+		ga.mutationRate = opts.MutationRate
+		ga.baseMutation = opts.MutationRate
+	}
+	if opts.CrossoverRate > 0 {
+		ga.crossoverRate = opts.CrossoverRate
+	}
+	if opts.Restarts > 0 {
+		ga.restarts = opts.Restarts
+	}
+}
+
+// SetObserver reports the best and mean fitness every interval generations
+func (ga *GeneticSolver) SetObserver(obs Observer, interval int) {
+	ga.trace = newTracer(obs, interval)
+}
+
+// observeGeneration sends a trace point for the evaluated population, given
+// the best fitness of the run's earlier generations
+func (ga *GeneticSolver) observeGeneration(bestBefore int) {
+	total := 0
+	for _, ind := range ga.population {
+		total += ind.fitness
+	}
+	best := ga.population[0].fitness
+	if bestBefore < best {
+		best = bestBefore
+	}
+	ga.trace.observe(TracePoint{
+		Run:       ga.stats.Restarts,
+		Iteration: ga.stats.Iterations,
+		Cost:      ga.population[0].fitness,
+		BestCost:  best,
+		MeanCost:  float64(total) / float64(len(ga.population)),
+	})
 }
 
 // Solve attempts to find a solution using genetic algorithm with restarts
 func (ga *GeneticSolver) Solve() bool {
+	solved, _ := ga.SolveContext(context.Background())
+	return solved
+}
+
+// SolveContext is Solve bounded by ctx, checked once per generation
+func (ga *GeneticSolver) SolveContext(ctx context.Context) (bool, error) {
+	done := ctx.Done()
+	ga.best = nil
+	ga.solution = nil
+	ga.solved = false
+	ga.stats.begin()
+
 	// Multiple runs for better success rate
 	for restart := 0; restart < ga.restarts; restart++ {
-		if ga.singleRun() {
-			return true
+		if restart > 0 {
+			ga.stats.Restarts++
+		}
+		if ga.singleRun(done) {
+			return true, nil
+		}
+		if isDone(done) {
+			return false, canceledError(ctx)
 		}
 	}
-	return false
+	return false, nil
 }
 
-// singleRun performs one complete genetic algorithm run
-func (ga *GeneticSolver) singleRun() bool {
+// singleRun performs one complete genetic algorithm run, stopping early
+// when done is closed
+func (ga *GeneticSolver) singleRun(done <-chan struct{}) bool {
 	// Initialize population
 	ga.initializePopulation()
 
@@ -69,6 +147,11 @@ func (ga *GeneticSolver) singleRun() bool {
 	for generation := 0; generation < ga.maxGenerations; generation++ {
 		// Evaluate fitness for all individuals
 		ga.evaluatePopulation()
+		ga.stats.Iterations++
+		ga.recordBest(ga.population[0].chromosome, ga.population[0].fitness, &ga.stats)
+		if ga.trace.due(ga.stats.Iterations) {
+			ga.observeGeneration(bestFitnessEver)
+		}
 
 		// Check if we found a solution
 		if ga.population[0].fitness == 0 {
@@ -91,16 +174,19 @@ func (ga *GeneticSolver) singleRun() bool {
 		if generationsWithoutImprovement > 50 {
 			break
 		}
+		if isDone(done) {
+			return false
+		}
 
 		// Adaptive parameters - more sophisticated approach
 		if generationsWithoutImprovement > 20 {
-			ga.mutationRate = 0.3 // Higher mutation
+			ga.mutationRate = 2 * ga.baseMutation // Higher mutation
 			// Add some new random individuals for diversity
 			for i := ga.populationSize * 4 / 5; i < ga.populationSize; i++ {
 				ga.initializeIndividual(i)
 			}
 		} else {
-			ga.mutationRate = 0.15
+			ga.mutationRate = ga.baseMutation
 		}
 
 		// Create new generation
@@ -110,6 +196,7 @@ func (ga *GeneticSolver) singleRun() bool {
 
 	// Check final generation
 	ga.evaluatePopulation()
+	ga.recordBest(ga.population[0].chromosome, ga.population[0].fitness, &ga.stats)
 	if ga.population[0].fitness == 0 {
 		ga.solution = make([]int, ga.n)
 		copy(ga.solution, ga.population[0].chromosome)
@@ -120,6 +207,12 @@ func (ga *GeneticSolver) singleRun() bool {
 	return false
 }
 
+// Stats returns the statistics of the last search. An iteration is one
+// generation, and every fitness computation counts as an evaluation.
+func (ga *GeneticSolver) Stats() SearchStats {
+	return ga.stats
+}
+
 // initializePopulation creates the initial population with better diversity
 func (ga *GeneticSolver) initializePopulation() {
 	for i := 0; i < ga.populationSize; i++ {
@@ -132,7 +225,7 @@ func (ga *GeneticSolver) initializeIndividual(index int) {
 	chromosome := make([]int, ga.n)
 
 	// Permutation initialization (one queen per row) - most effective for N-Queens
-	perm := rand.Perm(ga.n)
+	perm := ga.rng.Perm(ga.n)
 	copy(chromosome, perm)
 
 	ga.population[index] = Individual{
@@ -146,6 +239,7 @@ func (ga *GeneticSolver) evaluatePopulation() {
 	for i := 0; i < ga.populationSize; i++ {
 		ga.population[i].fitness = ga.calculateFitness(ga.population[i].chromosome)
 	}
+	ga.stats.Evaluations += int64(ga.populationSize)
 
 	// Sort population by fitness (ascending - lower is better)
 	sort.Slice(ga.population, func(i, j int) bool {
@@ -153,22 +247,11 @@ func (ga *GeneticSolver) evaluatePopulation() {
 	})
 }
 
-// calculateFitness calculates the fitness (number of conflicts) for a chromosome
+// calculateFitness calculates the fitness (number of conflicts) for a
+// chromosome in O(N) by loading it into the line counters
 func (ga *GeneticSolver) calculateFitness(chromosome []int) int {
-	conflicts := 0
-	for i := 0; i < ga.n; i++ {
-		for j := i + 1; j < ga.n; j++ {
-			// Check row conflict
-			if chromosome[i] == chromosome[j] {
-				conflicts++
-			}
-			// Check diagonal conflict
-			if abs(chromosome[i]-chromosome[j]) == abs(i-j) {
-				conflicts++
-			}
-		}
-	}
-	return conflicts
+	ga.state.reset(chromosome)
+	return ga.state.cost
 }
 
 // createNewGeneration creates a new generation through selection, crossover, and mutation
@@ -183,6 +266,9 @@ func (ga *GeneticSolver) createNewGeneration() []Individual {
 	if eliteSize > 10 {
 		eliteSize = 10
 	}
+	if eliteSize > ga.populationSize {
+		eliteSize = ga.populationSize // Tiny populations are all elite
+	}
 	for i := 0; i < eliteSize; i++ {
 		newPopulation[i] = Individual{
 			chromosome: make([]int, ga.n),
@@ -193,14 +279,14 @@ func (ga *GeneticSolver) createNewGeneration() []Individual {
 
 	// Generate rest of the population
 	for i := eliteSize; i < ga.populationSize; i++ {
-		if rand.Float64() < ga.crossoverRate {
+		if ga.rng.Float64() < ga.crossoverRate {
 			// Crossover
 			parent1 := ga.tournamentSelection()
 			parent2 := ga.tournamentSelection()
 			child := ga.smartCrossover(parent1, parent2)
 
 			// Mutation
-			if rand.Float64() < ga.mutationRate {
+			if ga.rng.Float64() < ga.mutationRate {
 				ga.smartMutation(child)
 			}
 
@@ -214,7 +300,7 @@ func (ga *GeneticSolver) createNewGeneration() []Individual {
 			child := make([]int, ga.n)
 			copy(child, parent.chromosome)
 
-			if rand.Float64() < ga.mutationRate {
+			if ga.rng.Float64() < ga.mutationRate {
 				ga.smartMutation(child)
 			}
 
@@ -235,9 +321,9 @@ func (ga *GeneticSolver) tournamentSelection() Individual {
 		tournamentSize = ga.populationSize
 	}
 
-	best := ga.population[rand.Intn(ga.populationSize)]
+	best := ga.population[ga.rng.Intn(ga.populationSize)]
 	for i := 1; i < tournamentSize; i++ {
-		candidate := ga.population[rand.Intn(ga.populationSize)]
+		candidate := ga.population[ga.rng.Intn(ga.populationSize)]
 		if candidate.fitness < best.fitness {
 			best = candidate
 		}
@@ -256,8 +342,8 @@ func (ga *GeneticSolver) orderCrossover(parent1, parent2 []int) []int {
 	child := make([]int, ga.n)
 
 	// Select a random segment from parent1
-	start := rand.Intn(ga.n)
-	end := rand.Intn(ga.n)
+	start := ga.rng.Intn(ga.n)
+	end := ga.rng.Intn(ga.n)
 	if start > end {
 		start, end = end, start
 	}
@@ -284,70 +370,55 @@ func (ga *GeneticSolver) orderCrossover(parent1, parent2 []int) []int {
 
 // smartMutation performs effective mutation
 func (ga *GeneticSolver) smartMutation(chromosome []int) {
-	strategy := rand.Float64()
+	strategy := ga.rng.Float64()
 
 	if strategy < 0.5 {
 		// Swap mutation (good for permutations)
-		pos1 := rand.Intn(ga.n)
-		pos2 := rand.Intn(ga.n)
+		pos1 := ga.rng.Intn(ga.n)
+		pos2 := ga.rng.Intn(ga.n)
 		chromosome[pos1], chromosome[pos2] = chromosome[pos2], chromosome[pos1]
 	} else if strategy < 0.8 {
 		// Smart mutation - move a conflicted queen
+		ga.state.reset(chromosome)
 		conflicts := make([]int, 0, ga.n)
 		for i := 0; i < ga.n; i++ {
-			if ga.calculateConflictsForPosition(chromosome, i) > 0 {
+			if ga.state.conflictsAt(i) > 0 {
 				conflicts = append(conflicts, i)
 			}
 		}
 
 		if len(conflicts) > 0 {
-			col := conflicts[rand.Intn(len(conflicts))]
+			col := conflicts[ga.rng.Intn(len(conflicts))]
 			// Try a few random positions and pick the best
 			bestRow := chromosome[col]
-			minConflicts := ga.calculateConflictsForPosition(chromosome, col)
+			minConflicts := ga.state.conflictsAt(col)
 
 			for attempts := 0; attempts < 3; attempts++ {
-				testRow := rand.Intn(ga.n)
+				testRow := ga.rng.Intn(ga.n)
 				if testRow != chromosome[col] {
-					originalRow := chromosome[col]
-					chromosome[col] = testRow
-					conflicts := ga.calculateConflictsForPosition(chromosome, col)
+					conflicts := ga.state.conflictsWith(col, testRow)
 					if conflicts < minConflicts {
 						minConflicts = conflicts
 						bestRow = testRow
 					}
-					chromosome[col] = originalRow
 				}
 			}
 			chromosome[col] = bestRow
 		} else {
 			// If no conflicts, random mutation
-			pos := rand.Intn(ga.n)
-			chromosome[pos] = rand.Intn(ga.n)
+			pos := ga.rng.Intn(ga.n)
+			chromosome[pos] = ga.rng.Intn(ga.n)
 		}
 	} else {
 		// Random mutation
-		pos := rand.Intn(ga.n)
-		chromosome[pos] = rand.Intn(ga.n)
+		pos := ga.rng.Intn(ga.n)
+		chromosome[pos] = ga.rng.Intn(ga.n)
 	}
 }
 
-// calculateConflictsForPosition calculates conflicts for a queen at a specific position
-func (ga *GeneticSolver) calculateConflictsForPosition(chromosome []int, col int) int {
-	conflicts := 0
-	for j := 0; j < ga.n; j++ {
-		if j != col {
-			// Check row conflict
-			if chromosome[col] == chromosome[j] {
-				conflicts++
-			}
-			// Check diagonal conflict
-			if abs(chromosome[col]-chromosome[j]) == abs(col-j) {
-				conflicts++
-			}
-		}
-	}
-	return conflicts
+// Best returns the fittest chromosome seen by the last search
+func (ga *GeneticSolver) Best() ([]int, int) {
+	return ga.best, ga.bestCost
 }
 
 // GetSolution returns the found solution
@@ -362,16 +433,5 @@ func (ga *GeneticSolver) PrintSolution() {
 		return
 	}
 
-	fmt.Printf("Genetic Algorithm Solution for N=%d:\n", ga.n)
-	for i := 0; i < ga.n; i++ {
-		for j := 0; j < ga.n; j++ {
-			if ga.solution[i] == j {
-				fmt.Print("Q ")
-			} else {
-				fmt.Print(". ")
-			}
-		}
-		fmt.Println()
-	}
-	fmt.Println()
+	printBoard("Genetic Algorithm Solution", ga.solution)
 }
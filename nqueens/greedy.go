@@ -0,0 +1,163 @@
+package nqueens
+
+import (
+	"context"
+	"fmt"
+)
+
+// GreedySolver implements hill climbing greedy search
+type GreedySolver struct {
+	n             int
+	board         []int       // Current board, owned by state
+	state         *boardState // Conflict counters for board
+	solution      []int
+	solved        bool
+	bestSeen      // Board with the fewest conflicts seen
+	maxIterations int
+	stats         SearchStats
+	trace         tracer
+	seededSource
+}
+
+// NewGreedySolver creates a new greedy solver
+func NewGreedySolver(n int) *GreedySolver {
+	state := newBoardState(n)
+	g := &GreedySolver{
+		n:             n,
+		board:         state.board,
+		state:         state,
+		maxIterations: 10000, // Prevent infinite loops
+	}
+	g.SetSeed(newSeed())
+	return g
+}
+
+// applyOptions overrides the defaults with any non-zero registry options
+func (g *GreedySolver) applyOptions(opts Options) {
+	if opts.Seed != 0 {
+		g.SetSeed(opts.Seed)
+	}
+	if opts.MaxIterations > 0 {
+		g.maxIterations = opts.MaxIterations
+	}
+}
+
+// SetObserver reports the conflicts on the board every interval iterations.
+// The best cost is over the whole search, since restarts keep no state.
+func (g *GreedySolver) SetObserver(obs Observer, interval int) {
+	g.trace = newTracer(obs, interval)
+}
+
+// Solve attempts to find a solution using hill climbing
+func (g *GreedySolver) Solve() bool {
+	solved, _ := g.SolveContext(context.Background())
+	return solved
+}
+
+// SolveContext is Solve bounded by ctx, checked once per iteration
+func (g *GreedySolver) SolveContext(ctx context.Context) (bool, error) {
+	done := ctx.Done()
+	g.best = nil
+	g.solution = nil
+	g.solved = false
+	g.stats.begin()
+
+	// Initialize with random positions
+	g.randomInit()
+	g.recordBest(g.board, g.countConflicts(), &g.stats)
+
+	for iter := 0; iter < g.maxIterations; iter++ {
+		if isDone(done) {
+			return false, canceledError(ctx)
+		}
+		g.stats.Iterations++
+
+		conflicts := g.countConflicts()
+		g.recordBest(g.board, conflicts, &g.stats)
+		if g.trace.due(g.stats.Iterations) {
+			g.trace.observe(TracePoint{
+				Run:       g.stats.Restarts,
+				Iteration: g.stats.Iterations,
+				Cost:      conflicts,
+				BestCost:  g.bestCost,
+			})
+		}
+		if conflicts == 0 {
+			g.solution = make([]int, g.n)
+			copy(g.solution, g.board)
+			g.solved = true
+			return true, nil
+		}
+
+		// Find the best neighbor, scoring each single-queen move in O(1)
+		bestCol, bestRow := -1, -1
+		bestConflicts := conflicts
+
+		for col := 0; col < g.n; col++ {
+			for newRow := 0; newRow < g.n; newRow++ {
+				if g.board[col] == newRow {
+					continue
+				}
+
+				// Try moving queen in column col to row newRow
+				newConflicts := conflicts + g.state.moveDelta(col, newRow)
+				if newConflicts < bestConflicts {
+					bestConflicts = newConflicts
+					bestCol, bestRow = col, newRow
+				}
+			}
+		}
+
+		g.stats.Evaluations += int64(g.n * (g.n - 1))
+
+		// If no improvement found, restart with random configuration
+		if bestConflicts >= conflicts {
+			g.randomInit()
+			g.stats.Restarts++
+		} else {
+			g.state.move(bestCol, bestRow)
+			g.stats.Accepted++
+		}
+	}
+
+	return false, nil
+}
+
+// Stats returns the statistics of the last search. An iteration is one scan
+// of the neighborhood, and a restart happens at every local optimum.
+func (g *GreedySolver) Stats() SearchStats {
+	return g.stats
+}
+
+// randomInit initializes the board with random queen positions
+func (g *GreedySolver) randomInit() {
+	for i := 0; i < g.n; i++ {
+		g.board[i] = g.rng.Intn(g.n)
+	}
+	g.state.reset(g.board)
+}
+
+// countConflicts counts the total number of conflicts on the board
+func (g *GreedySolver) countConflicts() int {
+	return g.state.cost
+}
+
+// Best returns the board with the fewest conflicts seen by the last search
+func (g *GreedySolver) Best() ([]int, int) {
+	return g.best, g.bestCost
+}
+
+// GetSolution returns the found solution
+func (g *GreedySolver) GetSolution() []int {
+	return g.solution
+}
+
+// PrintSolution prints the solution board
+func (g *GreedySolver) PrintSolution() {
+	if !g.solved {
+		fmt.Println("No solution found")
+		return
+	}
+
+	printBoard("Greedy Search Solution", g.solution)
+}
@@ -0,0 +1,38 @@
+package nqueens
+
+// knownSolutionCounts holds the number of solutions for N = 0..27 (OEIS A000170)
+var knownSolutionCounts = []int64{
+	1, 1, 0, 0, 2, 10, 4, 40, 92, 352,
+	724, 2680, 14200, 73712, 365596, 2279184, 14772512, 95815104,
+	666090624, 4968057848, 39029188884, 314666222712, 2691008701644,
+	24233937684440, 227514171973736, 2207893435808352, 22317699616364044,
+	234907967154122528,
+}
+
+// knownFundamentalCounts holds the number of solutions unique up to the
+// board symmetries for N = 0..27 (OEIS A002562, with a(0) = 1)
+var knownFundamentalCounts = []int64{
+	1, 1, 0, 0, 1, 2, 1, 6, 12, 46,
+	92, 341, 1787, 9233, 45752, 285053, 1846955, 11977939,
+	83263591, 621012754, 4878666808, 39333324973, 336376244042,
+	3029242658210, 28439272956934, 275986683743434, 2789712466510289,
+	29363495934315694,
+}
+
+// KnownSolutionCount returns the published number of solutions for an N×N
+// board (OEIS A000170) and whether N is covered by the table
+func KnownSolutionCount(n int) (int64, bool) {
+	if n < 0 || n >= len(knownSolutionCounts) {
+		return 0, false
+	}
+	return knownSolutionCounts[n], true
+}
+
+// KnownFundamentalCount returns the published number of fundamental
+// solutions for an N×N board (OEIS A002562) and whether N is covered
+func KnownFundamentalCount(n int) (int64, bool) {
+	if n < 0 || n >= len(knownFundamentalCounts) {
+		return 0, false
+	}
+	return knownFundamentalCounts[n], true
+}
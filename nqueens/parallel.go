@@ -0,0 +1,95 @@
+package nqueens
+
+import (
+	"runtime"
+	"sync"
+)
+
+// CountSolutionsParallel counts every solution like CountSolutions, but
+// splits the search tree at the first rows into independent subproblems and
+// spreads them over a pool of workers. Each subtree count is stored in its
+// own slot and the slots are summed in order, so the result is identical to
+// the sequential count. workers <= 0 uses one worker per CPU.
+func (e *ExhaustiveSearchSolver) CountSolutionsParallel(workers int) int64 {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	prefixes := splitPrefixes(e.n, splitDepth(e.n))
+	counts := make([]int64, len(prefixes))
+	if workers > len(prefixes) {
+		workers = len(prefixes)
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Engines keep per-search state, so every worker owns one
+			search := newPlacementSearch(e.n)
+			for job := range jobs {
+				counts[job] = search.run(nil, prefixes[job], nil)
+			}
+		}()
+	}
+	for job := range prefixes {
+		jobs <- job
+	}
+	close(jobs)
+	wg.Wait()
+
+	var total int64
+	for _, count := range counts {
+		total += count
+	}
+	e.solutionCount = total
+	return total
+}
+
+// splitDepth returns how many rows to fix per subproblem: two rows give
+// roughly N² subtrees, enough to keep all workers busy, except on tiny boards
+func splitDepth(n int) int {
+	if n < 6 {
+		return 0
+	}
+	return 2
+}
+
+// splitPrefixes lists every conflict-free placement of the first depth rows
+// in lexicographic order
+func splitPrefixes(n, depth int) [][]int {
+	if depth == 0 {
+		return [][]int{nil}
+	}
+
+	var prefixes [][]int
+	board := make([]int, depth)
+	var place func(row int)
+	place = func(row int) {
+		if row == depth {
+			prefixes = append(prefixes, append([]int(nil), board...))
+			return
+		}
+		for col := 0; col < n; col++ {
+			if safePrefix(board[:row], col) {
+				board[row] = col
+				place(row + 1)
+			}
+		}
+	}
+	place(0)
+	return prefixes
+}
+
+// safePrefix checks if a queen in the row after prefix may go on col
+func safePrefix(prefix []int, col int) bool {
+	row := len(prefix)
+	for i, c := range prefix {
+		if c == col || abs(c-col) == row-i {
+			return false
+		}
+	}
+	return true
+}
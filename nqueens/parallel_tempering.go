@@ -0,0 +1,249 @@
+package nqueens
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+)
+
+// ParallelTemperingSolver implements parallel tempering (replica
+// exchange): several annealing chains run concurrently, each at a fixed
+// temperature of a geometric ladder, and every exchangeInterval steps
+// neighboring temperatures swap their boards with the Metropolis
+// probability min(1, exp((1/Ti - 1/Tj)(Ei - Ej))). Hot replicas wander
+// freely while cold ones refine, and good boards found hot migrate down
+// the ladder. Each replica owns a random source derived from the solver's
+// seed and swaps are decided between rounds, so a seeded search is
+// reproducible however the goroutines are scheduled.
+type ParallelTemperingSolver struct {
+	n                int
+	replicas         int
+	minTemp          float64 // Temperature of the coldest replica
+	maxTemp          float64 // Temperature of the hottest replica
+	maxIterations    int     // Steps per replica
+	exchangeInterval int     // Steps per replica between exchange attempts
+	solution         []int
+	solved           bool
+	bestSeen                 // Board with the fewest conflicts over all replicas
+	swapTried        []int64 // Exchange attempts per neighboring pair
+	swapDone         []int64 // Accepted exchanges per neighboring pair
+	stats            SearchStats
+	seededSource
+}
+
+// replica is one annealing chain. Replicas move between the slots of the
+// temperature ladder when they are exchanged; their board, random source
+// and counters go with them.
+type replica struct {
+	state    *boardState
+	rng      *rand.Rand
+	best     []int // Board with the fewest conflicts seen by this chain
+	bestCost int
+
+	iterations, accepted, rejected int64 // Counted since the last round
+}
+
+// NewParallelTemperingSolver creates a new parallel tempering solver
+func NewParallelTemperingSolver(n int) *ParallelTemperingSolver {
+	pt := &ParallelTemperingSolver{
+		n:                n,
+		replicas:         8,
+		minTemp:          0.3,
+		maxTemp:          3,
+		maxIterations:    n * 1000, // As simulated annealing, per replica
+		exchangeInterval: 100,
+	}
+	pt.SetSeed(newSeed())
+	return pt
+}
+
+// applyOptions overrides the defaults with any non-zero registry options
+func (pt *ParallelTemperingSolver) applyOptions(opts Options) {
+	if opts.Seed != 0 {
+		pt.SetSeed(opts.Seed)
+	}
+	if opts.MaxIterations > 0 {
+		pt.maxIterations = opts.MaxIterations
+	}
+	if opts.Replicas > 0 {
+		pt.replicas = opts.Replicas
+	}
+}
+
+// Temperatures returns the ladder, from the coldest replica to the hottest
+func (pt *ParallelTemperingSolver) Temperatures() []float64 {
+	ladder := make([]float64, pt.replicas)
+	for i := range ladder {
+		if pt.replicas == 1 {
+			ladder[i] = pt.minTemp
+			break
+		}
+		ladder[i] = pt.minTemp * math.Pow(pt.maxTemp/pt.minTemp, float64(i)/float64(pt.replicas-1))
+	}
+	return ladder
+}
+
+// SwapRates returns the share of accepted exchanges between each pair of
+// neighboring temperatures in the last search, coldest pair first. Rates
+// near 0 mean the ladder is too sparse there.
+func (pt *ParallelTemperingSolver) SwapRates() []float64 {
+	rates := make([]float64, len(pt.swapTried))
+	for i, tried := range pt.swapTried {
+		if tried > 0 {
+			rates[i] = float64(pt.swapDone[i]) / float64(tried)
+		}
+	}
+	return rates
+}
+
+// Solve attempts to find a solution using parallel tempering
+func (pt *ParallelTemperingSolver) Solve() bool {
+	solved, _ := pt.SolveContext(context.Background())
+	return solved
+}
+
+// SolveContext is Solve bounded by ctx, checked by every replica once per
+// step
+func (pt *ParallelTemperingSolver) SolveContext(ctx context.Context) (bool, error) {
+	done := ctx.Done()
+	pt.best = nil
+	pt.solution = nil
+	pt.solved = false
+	pt.stats.begin()
+
+	ladder := pt.Temperatures()
+	pt.swapTried = make([]int64, len(ladder)-1)
+	pt.swapDone = make([]int64, len(ladder)-1)
+	replicas := make([]*replica, len(ladder))
+	for i := range replicas {
+		r := &replica{
+			state: newBoardState(pt.n),
+			rng:   rand.New(rand.NewSource(pt.rng.Int63())),
+		}
+		r.state.reset(r.rng.Perm(pt.n))
+		r.best = make([]int, pt.n)
+		copy(r.best, r.state.board)
+		r.bestCost = r.state.cost
+		replicas[i] = r
+	}
+	if pt.collect(replicas) {
+		return true, nil
+	}
+
+	var wg sync.WaitGroup
+	for round := 0; round*pt.exchangeInterval < pt.maxIterations; round++ {
+		steps := min(pt.exchangeInterval, pt.maxIterations-round*pt.exchangeInterval)
+		for slot, r := range replicas {
+			wg.Add(1)
+			go func(r *replica, temperature float64) {
+				defer wg.Done()
+				r.sweep(steps, temperature, done)
+			}(r, ladder[slot])
+		}
+		wg.Wait()
+
+		if pt.collect(replicas) {
+			return true, nil
+		}
+		if isDone(done) {
+			return false, canceledError(ctx)
+		}
+		pt.exchange(replicas, ladder, round%2)
+	}
+	return false, nil
+}
+
+// sweep runs steps Metropolis steps at temperature, using the neighbor
+// generator of simulated annealing with its default mix. It stops early on
+// a solution or when done is closed.
+func (r *replica) sweep(steps int, temperature float64, done <-chan struct{}) {
+	swapBelow, conflictBelow := DefaultNeighborMix.thresholds()
+	for i := 0; i < steps && r.state.cost > 0; i++ {
+		if isDone(done) {
+			return
+		}
+		r.iterations++
+
+		move := smartNeighbor(r.state, r.rng, swapBelow, conflictBelow)
+		delta := move.delta(r.state)
+		if delta > 0 && math.Exp(-float64(delta)/temperature) <= r.rng.Float64() {
+			r.rejected++
+			continue
+		}
+		move.apply(r.state)
+		r.accepted++
+		if r.state.cost < r.bestCost {
+			r.bestCost = r.state.cost
+			copy(r.best, r.state.board)
+		}
+	}
+}
+
+// collect adds the counters of a round to the statistics and records the
+// best board among the replicas, reporting whether it is a solution. The
+// lowest slot wins ties, so the result does not depend on scheduling.
+func (pt *ParallelTemperingSolver) collect(replicas []*replica) bool {
+	var bestOf *replica
+	for _, r := range replicas {
+		pt.stats.Iterations += r.iterations
+		pt.stats.Evaluations += r.iterations
+		pt.stats.Accepted += r.accepted
+		pt.stats.Rejected += r.rejected
+		r.iterations, r.accepted, r.rejected = 0, 0, 0
+		if bestOf == nil || r.bestCost < bestOf.bestCost {
+			bestOf = r
+		}
+	}
+
+	pt.recordBest(bestOf.best, bestOf.bestCost, &pt.stats)
+	if pt.bestCost == 0 {
+		pt.solution = make([]int, pt.n)
+		copy(pt.solution, pt.best)
+		pt.solved = true
+		return true
+	}
+	return false
+}
+
+// exchange attempts to swap the replicas of neighboring slots, pairing
+// slots (0,1), (2,3), ... when parity is 0 and (1,2), (3,4), ... when it
+// is 1, so that every pair is tried every other round
+func (pt *ParallelTemperingSolver) exchange(replicas []*replica, ladder []float64, parity int) {
+	for i := parity; i+1 < len(replicas); i += 2 {
+		pt.swapTried[i]++
+		cold, hot := replicas[i], replicas[i+1]
+		delta := (1/ladder[i] - 1/ladder[i+1]) * float64(cold.state.cost-hot.state.cost)
+		if delta >= 0 || math.Exp(delta) > pt.rng.Float64() {
+			replicas[i], replicas[i+1] = hot, cold
+			pt.swapDone[i]++
+		}
+	}
+}
+
+// Stats returns the statistics of the last search, summed over replicas.
+// Each iteration scores one neighbor, which is then accepted or rejected.
+func (pt *ParallelTemperingSolver) Stats() SearchStats {
+	return pt.stats
+}
+
+// Best returns the board with the fewest conflicts seen by the last search
+func (pt *ParallelTemperingSolver) Best() ([]int, int) {
+	return pt.best, pt.bestCost
+}
+
+// GetSolution returns the found solution
+func (pt *ParallelTemperingSolver) GetSolution() []int {
+	return pt.solution
+}
+
+// PrintSolution prints the solution board
+func (pt *ParallelTemperingSolver) PrintSolution() {
+	if !pt.solved {
+		fmt.Println("No solution found")
+		return
+	}
+
+	printBoard("Parallel Tempering Solution", pt.solution)
+}
@@ -0,0 +1,390 @@
+package nqueens
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// SimulatedAnnealingSolver implements simulated annealing search. The
+// temperature follows a pluggable CoolingSchedule, "reheat" by default.
+type SimulatedAnnealingSolver struct {
+	n             int
+	board         []int       // Current board, owned by state
+	state         *boardState // Conflict counters for board
+	solution      []int
+	solved        bool
+	bestSeen      // Board with the fewest conflicts over all runs
+	initialTemp   float64
+	targetAccept  float64 // Calibrate the initial temperature when positive
+	coolingRate   float64
+	minTemp       float64
+	schedule      CoolingSchedule
+	maxIterations int
+	restarts      int
+	swapBelow     float64 // Draws below this propose a swap
+	conflictBelow float64 // Then draws below this move a conflicted queen
+	stats         SearchStats
+	trace         tracer
+	seededSource
+}
+
+// NewSimulatedAnnealingSolver creates a new simulated annealing solver
+// with DefaultAnnealingConfig
+func NewSimulatedAnnealingSolver(n int) *SimulatedAnnealingSolver {
+	return newSimulatedAnnealingSolver(n, DefaultAnnealingConfig(n))
+}
+
+// NewSimulatedAnnealingSolverWithConfig creates a simulated annealing
+// solver with the given parameters, or reports why they are invalid
+func NewSimulatedAnnealingSolverWithConfig(n int, cfg AnnealingConfig) (*SimulatedAnnealingSolver, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return newSimulatedAnnealingSolver(n, cfg), nil
+}
+
+// newSimulatedAnnealingSolver creates a solver with a validated configuration
+func newSimulatedAnnealingSolver(n int, cfg AnnealingConfig) *SimulatedAnnealingSolver {
+	state := newBoardState(n)
+	sa := &SimulatedAnnealingSolver{
+		n:     n,
+		board: state.board,
+		state: state,
+	}
+	sa.configure(cfg)
+	sa.SetSeed(newSeed())
+	return sa
+}
+
+// configure applies a validated configuration
+func (sa *SimulatedAnnealingSolver) configure(cfg AnnealingConfig) {
+	sa.initialTemp = cfg.InitialTemp
+	sa.targetAccept = cfg.TargetAcceptance
+	sa.coolingRate = cfg.CoolingRate
+	sa.minTemp = cfg.MinTemp
+	sa.maxIterations = cfg.MaxIterations
+	sa.restarts = cfg.Restarts
+	sa.schedule, _ = NewCoolingSchedule(cfg.Schedule)
+	sa.swapBelow, sa.conflictBelow = cfg.Mix.thresholds()
+}
+
+// applyOptions overrides the defaults with the registry options. Options
+// that fail Options.Validate are a programming error and panic, rather than
+// running with parameters other than the caller's.
+func (sa *SimulatedAnnealingSolver) applyOptions(opts Options) {
+	if opts.Seed != 0 {
+		sa.SetSeed(opts.Seed)
+	}
+	cfg := opts.AnnealingConfig(sa.n)
+	if err := cfg.Validate(); err != nil {
+		panic(fmt.Sprintf("nqueens: invalid simulated annealing options: %v", err))
+	}
+	sa.configure(cfg)
+}
+
+// SetSchedule replaces the cooling schedule used by later searches
+func (sa *SimulatedAnnealingSolver) SetSchedule(schedule CoolingSchedule) {
+	sa.schedule = schedule
+}
+
+// SetObserver reports the temperature and the current and best cost every
+// interval iterations
+func (sa *SimulatedAnnealingSolver) SetObserver(obs Observer, interval int) {
+	sa.trace = newTracer(obs, interval)
+}
+
+// Solve attempts to find a solution using simulated annealing with restarts
+func (sa *SimulatedAnnealingSolver) Solve() bool {
+	solved, _ := sa.SolveContext(context.Background())
+	return solved
+}
+
+// SolveContext is Solve bounded by ctx, checked once per iteration
+func (sa *SimulatedAnnealingSolver) SolveContext(ctx context.Context) (bool, error) {
+	done := ctx.Done()
+	sa.best = nil
+	sa.solution = nil
+	sa.solved = false
+	sa.stats.begin()
+
+	for restart := 0; restart < sa.restarts; restart++ {
+		if restart > 0 {
+			sa.stats.Restarts++
+		}
+		if sa.singleRun(done) {
+			return true, nil
+		}
+		if isDone(done) {
+			return false, canceledError(ctx)
+		}
+	}
+	return false, nil
+}
+
+// singleRun performs one complete simulated annealing run, stopping early
+// when done is closed
+func (sa *SimulatedAnnealingSolver) singleRun(done <-chan struct{}) bool {
+	// Initialize with better starting position
+	sa.smartInit()
+
+	initial := sa.initialTemp
+	if sa.targetAccept > 0 && sa.state.cost > 0 {
+		initial = sa.calibrate(sa.targetAccept)
+		sa.stats.InitialTemp = initial
+	}
+	temperature := sa.schedule.Start(CoolingParams{
+		Initial:       initial,
+		Min:           sa.minTemp,
+		Rate:          sa.coolingRate,
+		MaxIterations: sa.maxIterations,
+	})
+	currentCost := sa.state.cost
+	bestCost := currentCost
+	sa.stats.observe(bestCost)
+	bestBoard := make([]int, sa.n)
+	copy(bestBoard, sa.board)
+
+	for iter := 0; iter < sa.maxIterations && temperature > sa.minTemp; iter++ {
+		if currentCost == 0 {
+			sa.solution = make([]int, sa.n)
+			copy(sa.solution, sa.board)
+			sa.solved = true
+			sa.recordBest(sa.board, 0, &sa.stats)
+			return true
+		}
+		if isDone(done) {
+			break
+		}
+		sa.stats.Iterations++
+
+		// Generate better neighbor and its cost difference in O(1)
+		move := sa.generateSmartNeighbor()
+		deltaCost := move.delta(sa.state)
+		sa.stats.Evaluations++
+
+		// Accept or reject the neighbor
+		accepted := deltaCost <= 0 || sa.acceptanceProbability(deltaCost, temperature) > sa.rng.Float64()
+		if accepted {
+			move.apply(sa.state)
+			currentCost += deltaCost
+			sa.stats.Accepted++
+
+			// Track best solution found
+			if currentCost < bestCost {
+				bestCost = currentCost
+				copy(bestBoard, sa.board)
+				sa.stats.observe(bestCost)
+			}
+		} else {
+			sa.stats.Rejected++
+		}
+
+		if sa.trace.due(sa.stats.Iterations) {
+			sa.trace.observe(TracePoint{
+				Run:         sa.stats.Restarts,
+				Iteration:   sa.stats.Iterations,
+				Cost:        currentCost,
+				BestCost:    bestCost,
+				Temperature: temperature,
+			})
+		}
+
+		next, reheat := sa.schedule.Next(CoolingStep{
+			Iteration:   iter,
+			Temperature: temperature,
+			Accepted:    accepted,
+			Cost:        currentCost,
+			BestCost:    bestCost,
+		})
+		if reheat {
+			// Resume from the best board of the run
+			sa.state.reset(bestBoard)
+			currentCost = bestCost
+		}
+		temperature = next
+	}
+
+	// Check if we found a solution
+	sa.state.reset(bestBoard)
+	sa.recordBest(bestBoard, bestCost, &sa.stats)
+	if bestCost == 0 {
+		sa.solution = make([]int, sa.n)
+		copy(sa.solution, bestBoard)
+		sa.solved = true
+		return true
+	}
+
+	return false
+}
+
+// Stats returns the statistics of the last search. Each iteration scores
+// one neighbor, which is then accepted or rejected.
+func (sa *SimulatedAnnealingSolver) Stats() SearchStats {
+	return sa.stats
+}
+
+// smartInit initializes the board with a better starting position
+func (sa *SimulatedAnnealingSolver) smartInit() {
+	// Always start with permutation (one queen per row)
+	perm := sa.rng.Perm(sa.n)
+	sa.state.reset(perm)
+}
+
+// calibrationSamples is the number of neighbors scored to calibrate the
+// initial temperature
+const calibrationSamples = 200
+
+// calibrate samples neighbors of the current board without applying them
+// and returns the calibrated temperature for their uphill deltas. With no
+// uphill move among the samples it keeps the configured temperature.
+func (sa *SimulatedAnnealingSolver) calibrate(target float64) float64 {
+	var uphill []int
+	for i := 0; i < calibrationSamples; i++ {
+		if delta := sa.generateSmartNeighbor().delta(sa.state); delta > 0 {
+			uphill = append(uphill, delta)
+		}
+	}
+	sa.stats.Evaluations += calibrationSamples
+	if len(uphill) == 0 {
+		return sa.initialTemp
+	}
+	return calibratedTemperature(uphill, target, sa.minTemp)
+}
+
+// calibratedTemperature returns the temperature above minTemp at which the
+// share target of the uphill deltas would be accepted, found by bisection.
+// When even minTemp accepts more than that share, no usable temperature
+// meets the target and the lowest one, just above minTemp, is returned.
+func calibratedTemperature(uphill []int, target, minTemp float64) float64 {
+	lowest := math.Nextafter(minTemp, math.Inf(1))
+	if uphillAcceptance(uphill, lowest) >= target {
+		return lowest
+	}
+	// uphillAcceptance(lo) < target <= uphillAcceptance(hi) holds
+	// throughout, so hi stays above lowest
+	lo, hi := lowest, 2*minTemp
+	for uphillAcceptance(uphill, hi) < target {
+		lo, hi = hi, hi*2
+	}
+	for i := 0; i < 50; i++ {
+		mid := (lo + hi) / 2
+		if uphillAcceptance(uphill, mid) < target {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return hi
+}
+
+// uphillAcceptance is the mean of exp(-delta/T) over the uphill deltas: the
+// share of them accepted at temperature T
+func uphillAcceptance(uphill []int, temperature float64) float64 {
+	sum := 0.0
+	for _, delta := range uphill {
+		sum += math.Exp(-float64(delta) / temperature)
+	}
+	return sum / float64(len(uphill))
+}
+
+// generateSmartNeighbor picks a neighbor with more intelligent strategies,
+// returned as a move so its cost can be scored without copying the board
+func (sa *SimulatedAnnealingSolver) generateSmartNeighbor() neighborMove {
+	return smartNeighbor(sa.state, sa.rng, sa.swapBelow, sa.conflictBelow)
+}
+
+// smartNeighbor is the neighbor generator of simulated annealing, shared
+// with the replicas of parallel tempering: a random swap, a conflicted
+// queen moved to its least attacked row, or a random queen moved to a
+// better row when there is one. A uniform draw below swapBelow picks the
+// first, below conflictBelow the second, and otherwise the third.
+func smartNeighbor(s *boardState, rng *rand.Rand, swapBelow, conflictBelow float64) neighborMove {
+	n := s.n
+	strategy := rng.Float64()
+
+	if strategy < swapBelow {
+		// Strategy 1: Swap two random queens (most effective for permutations)
+		pos1 := rng.Intn(n)
+		pos2 := rng.Intn(n)
+		for pos1 == pos2 {
+			pos2 = rng.Intn(n)
+		}
+		return neighborMove{col: pos1, other: pos2, swap: true}
+	} else if strategy < conflictBelow {
+		// Strategy 2: Move a conflicted queen to a better position
+		conflictedQueens := conflictedQueens(s)
+		if len(conflictedQueens) == 0 {
+			return neighborMove{col: 0, row: s.board[0]} // Leave the board as it is
+		}
+
+		col := conflictedQueens[rng.Intn(len(conflictedQueens))]
+		// Try to find a less conflicted row
+		bestRow := rng.Intn(n)
+		minConflicts := n * n
+
+		for row := 0; row < n; row++ {
+			if row != s.board[col] {
+				conflicts := s.conflictsWith(col, row)
+				if conflicts < minConflicts {
+					minConflicts = conflicts
+					bestRow = row
+				}
+			}
+		}
+		return neighborMove{col: col, row: bestRow}
+	}
+
+	// Strategy 3: Local search - try to improve a random position
+	col := rng.Intn(n)
+	bestRow := s.board[col]
+	minConflicts := s.conflictsAt(col)
+
+	for row := 0; row < n; row++ {
+		if row != s.board[col] {
+			conflicts := s.conflictsWith(col, row)
+			if conflicts < minConflicts {
+				minConflicts = conflicts
+				bestRow = row
+			}
+		}
+	}
+	return neighborMove{col: col, row: bestRow}
+}
+
+// conflictedQueens returns a list of column indices for queens that are in conflict
+func conflictedQueens(s *boardState) []int {
+	var conflicted []int
+	for i := 0; i < s.n; i++ {
+		if s.conflictsAt(i) > 0 {
+			conflicted = append(conflicted, i)
+		}
+	}
+	return conflicted
+}
+
+// acceptanceProbability calculates the probability of accepting a worse solution
+func (sa *SimulatedAnnealingSolver) acceptanceProbability(deltaCost int, temperature float64) float64 {
+	return math.Exp(-float64(deltaCost) / temperature)
+}
+
+// Best returns the board with the fewest conflicts seen by the last search
+func (sa *SimulatedAnnealingSolver) Best() ([]int, int) {
+	return sa.best, sa.bestCost
+}
+
+// GetSolution returns the found solution
+func (sa *SimulatedAnnealingSolver) GetSolution() []int {
+	return sa.solution
+}
+
+// PrintSolution prints the solution board
+func (sa *SimulatedAnnealingSolver) PrintSolution() {
+	if !sa.solved {
+		fmt.Println("No solution found")
+		return
+	}
+
+	printBoard("Simulated Annealing Solution", sa.solution)
+}
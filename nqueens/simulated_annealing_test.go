@@ -0,0 +1,41 @@
+package nqueens
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCalibratedTemperature(t *testing.T) {
+	tests := []struct {
+		uphill  []int
+		target  float64
+		minTemp float64
+	}{
+		{[]int{1, 1, 2, 3}, 0.2, 0.01},
+		{[]int{1, 1, 2, 3}, 0.5, 0.01},
+		{[]int{1, 1, 2, 3}, 0.8, 0.01},
+		{[]int{4}, 0.99, 0.01},
+		{[]int{1, 2, 6, 10}, 0.5, 1},
+	}
+	for _, tt := range tests {
+		temp := calibratedTemperature(tt.uphill, tt.target, tt.minTemp)
+		if !(temp > tt.minTemp) {
+			t.Errorf("%v, target %g: temperature %g is not above the minimum %g", tt.uphill, tt.target, temp, tt.minTemp)
+		}
+		if got := uphillAcceptance(tt.uphill, temp); math.Abs(got-tt.target) > 1e-6 {
+			t.Errorf("%v, target %g: acceptance %g at calibrated temperature %g", tt.uphill, tt.target, got, temp)
+		}
+	}
+}
+
+func TestCalibratedTemperatureUnreachableTarget(t *testing.T) {
+	// The minimum temperature of 5 already accepts 74% of these deltas
+	uphill := []int{1, 2}
+	temp := calibratedTemperature(uphill, 0.5, 5)
+	if !(temp > 5 && temp < 5.001) {
+		t.Errorf("temperature %g, want the lowest one above the minimum 5", temp)
+	}
+	if acceptance := uphillAcceptance(uphill, temp); acceptance < 0.5 || acceptance > 0.75 {
+		t.Errorf("acceptance %g at %g", acceptance, temp)
+	}
+}
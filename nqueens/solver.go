@@ -0,0 +1,280 @@
+package nqueens
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// ErrCanceled is returned by SolveContext, wrapped together with the
+// context's own error, when the context ends before the search does. Use
+// errors.Is with context.DeadlineExceeded to tell a timeout from a
+// cancellation.
+var ErrCanceled = errors.New("nqueens: search canceled")
+
+// Solver is the common interface implemented by every N-Queens algorithm
+type Solver interface {
+	// Solve searches for a placement and reports whether one was found
+	Solve() bool
+	// SolveContext is Solve bounded by ctx. If ctx ends before the search
+	// does, it returns an error wrapping ErrCanceled and ctx.Err().
+	SolveContext(ctx context.Context) (bool, error)
+	// Best returns the best board found so far by the last search and its
+	// number of conflicts, or nil before any search. It is the solution
+	// when the search succeeded.
+	Best() ([]int, int)
+	// GetSolution returns the found solution (nil if none was found)
+	GetSolution() []int
+	// Stats returns the statistics of the last search
+	Stats() SearchStats
+	// PrintSolution prints the solution board
+	PrintSolution()
+}
+
+// Algorithm describes a solver available in the registry
+type Algorithm struct {
+	Name        string                           // Short registry key, e.g. "sa"
+	DisplayName string                           // Human readable name used in reports
+	MaxN        int                              // Largest N the comparison runs it on (0 means no limit)
+	New         func(n int, opts Options) Solver // Expects options accepted by Options.Validate
+}
+
+// Options holds algorithm parameters passed through the registry. Zero
+// values keep the solver's defaults, and algorithms ignore the fields that
+// do not apply to them. The JSON form is the CLI's configuration file.
+type Options struct {
+	MaxIterations    int          `json:"max_iterations,omitempty"`    // Greedy, simulated annealing, min-conflicts, tabu; steps per replica for parallel tempering
+	Restarts         int          `json:"restarts,omitempty"`          // Simulated annealing, genetic, min-conflicts
+	InitialTemp      float64      `json:"initial_temp,omitempty"`      // Simulated annealing
+	CoolingRate      float64      `json:"cooling_rate,omitempty"`      // Simulated annealing
+	MinTemp          float64      `json:"min_temp,omitempty"`          // Simulated annealing
+	Schedule         string       `json:"schedule,omitempty"`          // Simulated annealing cooling schedule name
+	NeighborMix      *NeighborMix `json:"neighbor_mix,omitempty"`      // Simulated annealing; nil keeps the default
+	TargetAcceptance float64      `json:"target_acceptance,omitempty"` // Simulated annealing temperature calibration
+	PopulationSize   int          `json:"population_size,omitempty"`   // Genetic
+	Generations      int          `json:"generations,omitempty"`       // Genetic
+	MutationRate     float64      `json:"mutation_rate,omitempty"`     // Genetic
+	CrossoverRate    float64      `json:"crossover_rate,omitempty"`    // Genetic
+	Replicas         int          `json:"replicas,omitempty"`          // Parallel tempering
+	Tenure           int          `json:"tenure,omitempty"`            // Tabu search
+	Seed             int64        `json:"seed,omitempty"`              // Random seed for randomized solvers (0 picks one)
+}
+
+// Validate reports options that the named algorithm would reject for an
+// N×N board. Negative values are rejected for every algorithm, since zero
+// is the way to keep a default; the simulated annealing parameters are
+// only checked for "sa". NewSolver rejects the options that fail it.
+func (o Options) Validate(name string, n int) error {
+	for _, field := range []struct {
+		name  string
+		value float64
+	}{
+		{"maximum iterations", float64(o.MaxIterations)},
+		{"restarts", float64(o.Restarts)},
+		{"initial temperature", o.InitialTemp},
+		{"cooling rate", o.CoolingRate},
+		{"minimum temperature", o.MinTemp},
+		{"target acceptance", o.TargetAcceptance},
+		{"population size", float64(o.PopulationSize)},
+		{"generations", float64(o.Generations)},
+		{"mutation rate", o.MutationRate},
+		{"crossover rate", o.CrossoverRate},
+		{"replicas", float64(o.Replicas)},
+		{"tenure", float64(o.Tenure)},
+	} {
+		if field.value < 0 || math.IsNaN(field.value) {
+			return fmt.Errorf("%s %g must not be negative", field.name, field.value)
+		}
+	}
+	if name == "sa" {
+		if err := o.AnnealingConfig(n).Validate(); err != nil {
+			return fmt.Errorf("simulated annealing: %w", err)
+		}
+	}
+	return nil
+}
+
+// Seeded is implemented by the randomized solvers. Each owns its random
+// source, so two runs with the same seed and parameters are identical.
+type Seeded interface {
+	// SetSeed reseeds the solver's random source
+	SetSeed(seed int64)
+	// Seed returns the seed in use
+	Seed() int64
+}
+
+// newSeed picks a seed for solvers that were not given one
+func newSeed() int64 {
+	return rand.Int63()
+}
+
+// seededSource is embedded by the randomized solvers to implement Seeded
+type seededSource struct {
+	seed int64
+	rng  *rand.Rand
+}
+
+// SetSeed reseeds the solver's random source so runs can be replayed
+func (s *seededSource) SetSeed(seed int64) {
+	s.seed = seed
+	s.rng = rand.New(rand.NewSource(seed))
+}
+
+// SetRand injects the random source used by the solver. Seed reports 0
+// afterwards, since the seed of an injected source is not known.
+func (s *seededSource) SetRand(rng *rand.Rand) {
+	s.seed = 0
+	s.rng = rng
+}
+
+// Seed returns the seed of the solver's random source
+func (s *seededSource) Seed() int64 {
+	return s.seed
+}
+
+var (
+	registry      = make(map[string]Algorithm)
+	registryOrder []string
+)
+
+func init() {
+	Register(Algorithm{
+		Name:        "dfs",
+		DisplayName: "Exhaustive DFS",
+		MaxN:        30, // First-solution search time grows erratically beyond this
+		New:         func(n int, opts Options) Solver { return NewExhaustiveSearchSolver(n) },
+	})
+	Register(Algorithm{
+		Name:        "greedy",
+		DisplayName: "Greedy Hill Climbing",
+		MaxN:        50, // Full neighborhood scan becomes too slow beyond this
+		New: func(n int, opts Options) Solver {
+			s := NewGreedySolver(n)
+			s.applyOptions(opts)
+			return s
+		},
+	})
+	Register(Algorithm{
+		Name:        "sa",
+		DisplayName: "Simulated Annealing",
+		New: func(n int, opts Options) Solver {
+			s := NewSimulatedAnnealingSolver(n)
+			s.applyOptions(opts)
+			return s
+		},
+	})
+	Register(Algorithm{
+		Name:        "ga",
+		DisplayName: "Genetic Algorithm",
+		New: func(n int, opts Options) Solver {
+			s := NewGeneticSolver(n)
+			s.applyOptions(opts)
+			return s
+		},
+	})
+	Register(Algorithm{
+		Name:        "mc",
+		DisplayName: "Min-Conflicts",
+		New: func(n int, opts Options) Solver {
+			s := NewMinConflictsSolver(n)
+			s.applyOptions(opts)
+			return s
+		},
+	})
+	Register(Algorithm{
+		Name:        "pt",
+		DisplayName: "Parallel Tempering",
+		New: func(n int, opts Options) Solver {
+			s := NewParallelTemperingSolver(n)
+			s.applyOptions(opts)
+			return s
+		},
+	})
+	Register(Algorithm{
+		Name:        "tabu",
+		DisplayName: "Tabu Search",
+		MaxN:        500, // Each move scans O(N) neighbors of every conflicted queen
+		New: func(n int, opts Options) Solver {
+			s := NewTabuSearchSolver(n)
+			s.applyOptions(opts)
+			return s
+		},
+	})
+	Register(Algorithm{
+		Name:        "construct",
+		DisplayName: "Constructive",
+		New:         func(n int, opts Options) Solver { return NewConstructiveSolver(n) },
+	})
+}
+
+// canceledError builds the error returned when ctx stops a search
+func canceledError(ctx context.Context) error {
+	return fmt.Errorf("%w: %w", ErrCanceled, ctx.Err())
+}
+
+// isDone reports whether done has been closed, without blocking. A nil
+// channel, as returned by context.Background().Done(), is never done.
+func isDone(done <-chan struct{}) bool {
+	select {
+	case <-done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Register adds an algorithm to the registry, panicking on duplicate names
+func Register(a Algorithm) {
+	if a.Name == "" || a.New == nil {
+		panic("nqueens: Register requires a name and a constructor")
+	}
+	if _, exists := registry[a.Name]; exists {
+		panic(fmt.Sprintf("nqueens: algorithm %q registered twice", a.Name))
+	}
+	if a.DisplayName == "" {
+		a.DisplayName = a.Name
+	}
+	registry[a.Name] = a
+	registryOrder = append(registryOrder, a.Name)
+}
+
+// Lookup returns the algorithm registered under name
+func Lookup(name string) (Algorithm, bool) {
+	a, ok := registry[name]
+	return a, ok
+}
+
+// Algorithms returns all registered algorithms in registration order
+func Algorithms() []Algorithm {
+	algos := make([]Algorithm, 0, len(registryOrder))
+	for _, name := range registryOrder {
+		algos = append(algos, registry[name])
+	}
+	return algos
+}
+
+// AlgorithmNames returns the sorted registry keys
+func AlgorithmNames() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewSolver constructs the algorithm registered under name for an N×N
+// board, or reports why opts are invalid for it
+func NewSolver(name string, n int, opts Options) (Solver, error) {
+	a, ok := Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown algorithm %q (available: %v)", name, AlgorithmNames())
+	}
+	if err := opts.Validate(name, n); err != nil {
+		return nil, err
+	}
+	return a.New(n, opts), nil
+}
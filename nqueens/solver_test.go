@@ -0,0 +1,364 @@
+package nqueens_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"nqueen/nqueens"
+)
+
+// testSeed fixes the random source of the randomized solvers so that every
+// test run searches the same way
+const testSeed = 1
+
+// solvableSizes are the board sizes each algorithm is expected to solve
+// with testSeed and its default parameters
+var solvableSizes = map[string][]int{
+	"dfs":       {1, 4, 5, 6, 7, 8, 10, 12, 16, 20},
+	"greedy":    {1, 4, 5, 6, 8, 10, 12},
+	"sa":        {1, 4, 5, 6, 8, 10, 20, 50},
+	"ga":        {1, 4, 5, 6, 8, 10},
+	"mc":        {1, 4, 5, 6, 8, 10, 20, 50, 100, 1000},
+	"pt":        {1, 4, 5, 6, 8, 10, 20, 50, 100},
+	"tabu":      {1, 4, 5, 6, 8, 10, 20, 50, 100},
+	"construct": {1, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 100, 1000},
+}
+
+func TestSolversReturnValidBoards(t *testing.T) {
+	for _, algo := range nqueens.Algorithms() {
+		sizes, ok := solvableSizes[algo.Name]
+		if !ok {
+			t.Errorf("no test sizes for algorithm %q", algo.Name)
+			continue
+		}
+		for _, n := range sizes {
+			t.Run(fmt.Sprintf("%s/N=%d", algo.Name, n), func(t *testing.T) {
+				solver := algo.New(n, nqueens.Options{Seed: testSeed})
+				if !solver.Solve() {
+					t.Fatal("no solution found")
+				}
+				board := solver.GetSolution()
+				if len(board) != n {
+					t.Fatalf("solution has %d queens, want %d", len(board), n)
+				}
+				if report := nqueens.Validate(board); !report.Valid {
+					t.Fatalf("invalid solution %v: %v", board, report)
+				}
+				if best, conflicts := solver.Best(); conflicts != 0 || len(best) != n {
+					t.Errorf("Best() = %v with %d conflicts after a solve", best, conflicts)
+				}
+			})
+		}
+	}
+}
+
+func TestSolverStats(t *testing.T) {
+	for _, algo := range nqueens.Algorithms() {
+		if algo.Name == "construct" {
+			continue // No search to count
+		}
+		solver := algo.New(12, nqueens.Options{Seed: testSeed})
+		solver.Solve()
+		stats := solver.Stats()
+		if stats.Iterations <= 0 {
+			t.Errorf("%s: Iterations = %d after a solve", algo.Name, stats.Iterations)
+		}
+		if stats.Accepted+stats.Rejected > stats.Iterations && algo.Name != "greedy" {
+			t.Errorf("%s: %d accepted and %d rejected moves in %d iterations",
+				algo.Name, stats.Accepted, stats.Rejected, stats.Iterations)
+		}
+		for i := 1; i < len(stats.BestCosts); i++ {
+			if stats.BestCosts[i].Cost >= stats.BestCosts[i-1].Cost {
+				t.Errorf("%s: best cost trace %v does not decrease", algo.Name, stats.BestCosts)
+				break
+			}
+		}
+		if k := len(stats.BestCosts); algo.Name != "dfs" && (k == 0 || stats.BestCosts[k-1].Cost != 0) {
+			t.Errorf("%s: best cost trace %v does not end at 0", algo.Name, stats.BestCosts)
+		}
+	}
+}
+
+func TestNoSolutionForTwoAndThree(t *testing.T) {
+	for _, n := range []int{2, 3} {
+		for _, name := range []string{"dfs", "construct"} {
+			solver, err := nqueens.NewSolver(name, n, nqueens.Options{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if solver.Solve() {
+				t.Errorf("%s: N=%d solved with %v", name, n, solver.GetSolution())
+			}
+			if board := solver.GetSolution(); board != nil {
+				t.Errorf("%s: N=%d GetSolution() = %v, want nil", name, n, board)
+			}
+		}
+	}
+}
+
+func TestFailedSolveClearsSolution(t *testing.T) {
+	// A solver reused for a search that fails must not report the
+	// solution of the previous search
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, algo := range nqueens.Algorithms() {
+		solver := algo.New(8, nqueens.Options{Seed: testSeed})
+		if !solver.Solve() {
+			t.Fatalf("%s: N=8 not solved", algo.Name)
+		}
+		if solved, _ := solver.SolveContext(canceled); solved {
+			continue
+		}
+		if board := solver.GetSolution(); board != nil {
+			t.Errorf("%s: GetSolution() = %v after a canceled search", algo.Name, board)
+		}
+	}
+}
+
+func TestSeededSolversAreReproducible(t *testing.T) {
+	for _, algo := range nqueens.Algorithms() {
+		first := algo.New(8, nqueens.Options{Seed: testSeed})
+		if _, ok := first.(nqueens.Seeded); !ok {
+			continue
+		}
+		second := algo.New(8, nqueens.Options{Seed: testSeed})
+		first.Solve()
+		second.Solve()
+		if a, b := fmt.Sprint(first.GetSolution()), fmt.Sprint(second.GetSolution()); a != b {
+			t.Errorf("%s: seed %d gave %s and %s", algo.Name, testSeed, a, b)
+		}
+	}
+}
+
+func TestConstructIsValid(t *testing.T) {
+	for n := 1; n <= 500; n++ {
+		board := nqueens.Construct(n)
+		if n == 2 || n == 3 {
+			if board != nil {
+				t.Errorf("Construct(%d) = %v, want nil", n, board)
+			}
+			continue
+		}
+		if report := nqueens.Validate(board); !report.Valid || len(board) != n {
+			t.Errorf("Construct(%d): %v", n, report)
+		}
+	}
+}
+
+func TestCoolingSchedulesSolve(t *testing.T) {
+	for _, name := range nqueens.CoolingScheduleNames() {
+		solver := nqueens.NewSimulatedAnnealingSolver(8)
+		solver.SetSeed(testSeed)
+		schedule, err := nqueens.NewCoolingSchedule(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		solver.SetSchedule(schedule)
+		if !solver.Solve() {
+			t.Errorf("%s: N=8 not solved", name)
+			continue
+		}
+		if report := nqueens.Validate(solver.GetSolution()); !report.Valid {
+			t.Errorf("%s: invalid solution: %v", name, report)
+		}
+	}
+	if _, err := nqueens.NewCoolingSchedule("unknown"); err == nil {
+		t.Error("NewCoolingSchedule accepted an unknown name")
+	}
+}
+
+func TestParallelTemperingLadder(t *testing.T) {
+	solver := nqueens.NewParallelTemperingSolver(20)
+	solver.SetSeed(testSeed)
+	ladder := solver.Temperatures()
+	for i := 1; i < len(ladder); i++ {
+		if ladder[i] <= ladder[i-1] {
+			t.Fatalf("temperatures %v do not increase", ladder)
+		}
+	}
+	if !solver.Solve() {
+		t.Fatal("N=20 not solved")
+	}
+	if rates := solver.SwapRates(); len(rates) != len(ladder)-1 {
+		t.Errorf("%d swap rates for %d temperatures", len(rates), len(ladder))
+	}
+}
+
+func TestAnnealingConfigValidation(t *testing.T) {
+	for _, n := range []int{0, 1, 8} {
+		if err := nqueens.DefaultAnnealingConfig(n).Validate(); err != nil {
+			t.Fatalf("default config for N=%d rejected: %v", n, err)
+		}
+	}
+	if nqueens.NewSimulatedAnnealingSolver(0) == nil {
+		t.Fatal("NewSimulatedAnnealingSolver(0) returned nil")
+	}
+	invalid := map[string]func(*nqueens.AnnealingConfig){
+		"initial below minimum": func(c *nqueens.AnnealingConfig) { c.InitialTemp = c.MinTemp / 2 },
+		"zero minimum":          func(c *nqueens.AnnealingConfig) { c.MinTemp = 0 },
+		"cooling above 1":       func(c *nqueens.AnnealingConfig) { c.CoolingRate = 1.5 },
+		"no iterations":         func(c *nqueens.AnnealingConfig) { c.MaxIterations = 0 },
+		"no runs":               func(c *nqueens.AnnealingConfig) { c.Restarts = 0 },
+		"unknown schedule":      func(c *nqueens.AnnealingConfig) { c.Schedule = "unknown" },
+		"negative weight":       func(c *nqueens.AnnealingConfig) { c.Mix.Local = -1 },
+		"zero weights":          func(c *nqueens.AnnealingConfig) { c.Mix = nqueens.NeighborMix{} },
+	}
+	for name, change := range invalid {
+		cfg := nqueens.DefaultAnnealingConfig(8)
+		change(&cfg)
+		if _, err := nqueens.NewSimulatedAnnealingSolverWithConfig(8, cfg); err == nil {
+			t.Errorf("%s: config accepted", name)
+		}
+	}
+
+	opts := nqueens.Options{CoolingRate: 0.95, NeighborMix: &nqueens.NeighborMix{Swap: 1}}
+	cfg := opts.AnnealingConfig(8)
+	if cfg.CoolingRate != 0.95 || cfg.Mix.Swap != 1 || cfg.InitialTemp != 64 {
+		t.Errorf("Options.AnnealingConfig(8) = %+v", cfg)
+	}
+	solver, err := nqueens.NewSimulatedAnnealingSolverWithConfig(8, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	solver.SetSeed(testSeed)
+	if !solver.Solve() {
+		t.Error("N=8 not solved with swaps only")
+	}
+}
+
+func TestAnnealingCalibration(t *testing.T) {
+	cfg := nqueens.DefaultAnnealingConfig(100)
+	cfg.TargetAcceptance = 0.8
+	cfg.CoolingRate = 0.999
+	solver, err := nqueens.NewSimulatedAnnealingSolverWithConfig(100, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	solver.SetSeed(testSeed)
+	if !solver.Solve() {
+		t.Error("N=100 not solved with a calibrated temperature")
+	}
+	// Uphill moves change the cost by a few conflicts, so 80% acceptance
+	// needs a temperature of a few units rather than N×N
+	if temp := solver.Stats().InitialTemp; !(temp > cfg.MinTemp && temp < 100) {
+		t.Errorf("calibrated initial temperature %g", temp)
+	}
+
+	cfg.TargetAcceptance = 1
+	if err := cfg.Validate(); err == nil {
+		t.Error("target acceptance 1 accepted")
+	}
+	plain := nqueens.NewSimulatedAnnealingSolver(20)
+	plain.SetSeed(testSeed)
+	plain.Solve()
+	if temp := plain.Stats().InitialTemp; temp != 0 {
+		t.Errorf("uncalibrated solver reports initial temperature %g", temp)
+	}
+}
+
+func TestTabuTenure(t *testing.T) {
+	// A tenure longer than the search bars every move back eventually,
+	// which must not stall the search
+	for _, tenure := range []int{1, 5, 1000} {
+		solver, err := nqueens.NewSolver("tabu", 12, nqueens.Options{Seed: testSeed, Tenure: tenure})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !solver.Solve() {
+			t.Errorf("tenure %d: N=12 not solved", tenure)
+			continue
+		}
+		if report := nqueens.Validate(solver.GetSolution()); !report.Valid {
+			t.Errorf("tenure %d: invalid solution: %v", tenure, report)
+		}
+	}
+}
+
+func TestGeneticTinyPopulation(t *testing.T) {
+	// Populations smaller than the elite must not index past the end
+	for _, pop := range []int{1, 2, 3} {
+		solver, err := nqueens.NewSolver("ga", 8, nqueens.Options{Seed: testSeed, PopulationSize: pop, Generations: 20})
+		if err != nil {
+			t.Fatal(err)
+		}
+		solver.Solve()
+		if best, _ := solver.Best(); len(best) != 8 {
+			t.Errorf("population %d: Best() = %v", pop, best)
+		}
+	}
+}
+
+func TestAnnealingCalibrationAboveMinimum(t *testing.T) {
+	// At a high minimum temperature even the minimum accepts more than the
+	// target share, so the runs start as cool as they can, not at the
+	// configured 900
+	cfg := nqueens.DefaultAnnealingConfig(30)
+	cfg.MinTemp = 5
+	cfg.InitialTemp = 900
+	cfg.TargetAcceptance = 0.5
+	solver, err := nqueens.NewSimulatedAnnealingSolverWithConfig(30, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	solver.SetSeed(testSeed)
+	solver.Solve()
+	stats := solver.Stats()
+	if !(stats.InitialTemp > cfg.MinTemp && stats.InitialTemp < cfg.MinTemp*1.001) {
+		t.Errorf("calibrated initial temperature %g is not just above the minimum %g", stats.InitialTemp, cfg.MinTemp)
+	}
+	if stats.Iterations == 0 {
+		t.Error("calibrated runs made no moves")
+	}
+}
+
+func TestOptionsValidate(t *testing.T) {
+	for _, opts := range []nqueens.Options{
+		{CoolingRate: -1},
+		{Restarts: -4},
+		{PopulationSize: -1},
+		{Tenure: -2},
+	} {
+		for _, name := range []string{"sa", "ga"} {
+			if err := opts.Validate(name, 10); err == nil {
+				t.Errorf("%s: %+v accepted", name, opts)
+			}
+		}
+	}
+
+	// Annealing parameters only matter when simulated annealing runs
+	hot := nqueens.Options{MinTemp: 500}
+	if err := hot.Validate("ga", 10); err != nil {
+		t.Errorf("ga rejected an annealing option: %v", err)
+	}
+	if err := hot.Validate("sa", 10); err == nil {
+		t.Error("sa accepted a minimum temperature above the initial one")
+	}
+}
+
+func TestNewSolverRejectsInvalidOptions(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		opts nqueens.Options
+		ok   bool
+	}{
+		{"sa", nqueens.Options{CoolingRate: 1.5}, false},
+		{"sa", nqueens.Options{Schedule: "unknown"}, false},
+		{"ga", nqueens.Options{CoolingRate: 1.5}, true}, // Not an option of ga
+		{"ga", nqueens.Options{PopulationSize: -1}, false},
+		{"sa", nqueens.Options{CoolingRate: 0.9}, true},
+	} {
+		solver, err := nqueens.NewSolver(tt.name, 8, tt.opts)
+		if ok := err == nil && solver != nil; ok != tt.ok {
+			t.Errorf("NewSolver(%q, 8, %+v) = %v, %v", tt.name, tt.opts, solver, err)
+		}
+	}
+
+	sa, _ := nqueens.Lookup("sa")
+	defer func() {
+		if recover() == nil {
+			t.Error("the registry constructor accepted an invalid cooling rate")
+		}
+	}()
+	sa.New(8, nqueens.Options{CoolingRate: 1.5})
+}
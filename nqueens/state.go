@@ -0,0 +1,147 @@
+package nqueens
+
+// boardState is a board with occupancy counters for every line, so that the
+// effect of moving or swapping queens is known in O(1) instead of by an
+// O(N²) recount. Queens sit one per index i with value board[i]; rows counts
+// the queens per value, diag per index-value diagonal and anti per
+// index+value diagonal. The number of attacking pairs is the sum of k(k-1)/2
+// over all lines holding k queens, which is exactly what CountConflicts
+// computes pairwise.
+type boardState struct {
+	n     int
+	board []int
+	rows  []int
+	diag  []int // Indexed by i - board[i] + n - 1
+	anti  []int // Indexed by i + board[i]
+	cost  int
+}
+
+// newBoardState creates an empty state for an N×N board; load a board with reset
+func newBoardState(n int) *boardState {
+	lines := 2*n - 1
+	if lines < 0 {
+		lines = 0
+	}
+	return &boardState{
+		n:     n,
+		board: make([]int, n),
+		rows:  make([]int, n),
+		diag:  make([]int, lines),
+		anti:  make([]int, lines),
+	}
+}
+
+// reset loads board into the state in O(N)
+func (s *boardState) reset(board []int) {
+	s.clear()
+	for i, v := range board {
+		s.place(i, v)
+	}
+}
+
+// clear empties the counters, leaving every queen to be placed again
+func (s *boardState) clear() {
+	for i := range s.rows {
+		s.rows[i] = 0
+	}
+	for i := range s.diag {
+		s.diag[i] = 0
+		s.anti[i] = 0
+	}
+	s.cost = 0
+}
+
+// place puts queen i on value v, which must currently be off the counters
+func (s *boardState) place(i, v int) {
+	d, a := i-v+s.n-1, i+v
+	s.cost += s.rows[v] + s.diag[d] + s.anti[a]
+	s.rows[v]++
+	s.diag[d]++
+	s.anti[a]++
+	s.board[i] = v
+}
+
+// lift takes queen i off the counters
+func (s *boardState) lift(i int) {
+	v := s.board[i]
+	d, a := i-v+s.n-1, i+v
+	s.rows[v]--
+	s.diag[d]--
+	s.anti[a]--
+	s.cost -= s.rows[v] + s.diag[d] + s.anti[a]
+}
+
+// conflictsAt returns the number of queens attacking queen i
+func (s *boardState) conflictsAt(i int) int {
+	v := s.board[i]
+	return s.rows[v] + s.diag[i-v+s.n-1] + s.anti[i+v] - 3
+}
+
+// conflictsWith returns the number of other queens that would attack queen
+// i if it stood on value v
+func (s *boardState) conflictsWith(i, v int) int {
+	if v == s.board[i] {
+		return s.conflictsAt(i)
+	}
+	return s.rows[v] + s.diag[i-v+s.n-1] + s.anti[i+v]
+}
+
+// moveDelta returns the change in cost if queen i moved to value v
+func (s *boardState) moveDelta(i, v int) int {
+	if v == s.board[i] {
+		return 0
+	}
+	return s.conflictsWith(i, v) - s.conflictsAt(i)
+}
+
+// move puts queen i on value v
+func (s *boardState) move(i, v int) {
+	s.lift(i)
+	s.place(i, v)
+}
+
+// swapDelta returns the change in cost if queens i and j exchanged values
+func (s *boardState) swapDelta(i, j int) int {
+	before := s.cost
+	s.swap(i, j)
+	delta := s.cost - before
+	s.swap(i, j)
+	return delta
+}
+
+// swap exchanges the values of queens i and j
+func (s *boardState) swap(i, j int) {
+	if i == j {
+		return
+	}
+	vi, vj := s.board[i], s.board[j]
+	s.lift(i)
+	s.lift(j)
+	s.place(i, vj)
+	s.place(j, vi)
+}
+
+// neighborMove is a candidate change to a board: queen col moves to row,
+// or, for a swap, queens col and other exchange their values
+type neighborMove struct {
+	col, row int
+	other    int
+	swap     bool
+}
+
+// delta returns the change in cost the move would cause
+func (m neighborMove) delta(s *boardState) int {
+	if m.swap {
+		return s.swapDelta(m.col, m.other)
+	}
+	return s.moveDelta(m.col, m.row)
+}
+
+// apply performs the move
+func (m neighborMove) apply(s *boardState) {
+	if m.swap {
+		s.swap(m.col, m.other)
+	} else {
+		s.move(m.col, m.row)
+	}
+}
@@ -0,0 +1,65 @@
+package nqueens
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// TestBoardStateMatchesRecount applies random moves and swaps to boards of
+// several sizes and checks the counters against a full recount after every
+// step, and each predicted delta against the change it actually caused
+func TestBoardStateMatchesRecount(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 8, 13} {
+		s := newBoardState(n)
+		board := make([]int, n)
+		for i := range board {
+			board[i] = rng.Intn(n)
+		}
+		s.reset(board)
+		if want := CountConflicts(s.board); s.cost != want {
+			t.Fatalf("N=%d: cost %d after reset of %v, want %d", n, s.cost, board, want)
+		}
+
+		for step := 0; step < 2000; step++ {
+			m := neighborMove{col: rng.Intn(n), row: rng.Intn(n)}
+			if rng.Intn(2) == 0 {
+				m = neighborMove{col: m.col, other: rng.Intn(n), swap: true}
+			}
+			before := s.cost
+			delta := m.delta(s)
+			if s.cost != before {
+				t.Fatalf("N=%d: delta of %+v changed the cost from %d to %d", n, m, before, s.cost)
+			}
+			m.apply(s)
+
+			want := CountConflicts(s.board)
+			if s.cost != want {
+				t.Fatalf("N=%d step %d: cost %d after %+v on %v, want %d", n, step, s.cost, m, s.board, want)
+			}
+			if delta != want-before {
+				t.Fatalf("N=%d step %d: delta %d for %+v, cost went from %d to %d", n, step, delta, m, before, want)
+			}
+			for i := 0; i < n; i++ {
+				if got, want := s.conflictsAt(i), queenConflicts(s.board, i); got != want {
+					t.Fatalf("N=%d step %d: conflictsAt(%d) = %d on %v, want %d", n, step, i, got, s.board, want)
+				}
+			}
+		}
+	}
+}
+
+// queenConflicts counts the queens attacking queen i pairwise
+func queenConflicts(board []int, i int) int {
+	count := 0
+	for j, v := range board {
+		if j == i {
+			continue
+		}
+		d := v - board[i]
+		if d == 0 || d == j-i || d == i-j {
+			count++
+		}
+	}
+	return count
+}
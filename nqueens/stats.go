@@ -0,0 +1,69 @@
+package nqueens
+
+import "time"
+
+// SearchStats describes the work done by a solver's last search. Counters
+// that do not apply to an algorithm stay zero.
+type SearchStats struct {
+	Nodes       int64 `json:"nodes,omitempty"`      // Search tree nodes expanded (dfs)
+	Backtracks  int64 `json:"backtracks,omitempty"` // Nodes with no solution below them (dfs)
+	Iterations  int64 `json:"iterations"`           // Main loop steps, summed over restarts
+	Restarts    int   `json:"restarts"`             // Fresh starts after the first
+	Evaluations int64 `json:"evaluations"`          // Candidate boards or moves scored
+	Accepted    int64 `json:"accepted,omitempty"`   // Moves applied to the board
+	Rejected    int64 `json:"rejected,omitempty"`   // Moves scored but not applied
+
+	// InitialTemp is the calibrated starting temperature of the last
+	// annealing run (sa with AnnealingConfig.TargetAcceptance set)
+	InitialTemp float64 `json:"initial_temp,omitempty"`
+
+	// BestCosts traces the best number of conflicts seen, with one sample
+	// each time it improved
+	BestCosts []CostSample `json:"best_costs,omitempty"`
+
+	start time.Time
+}
+
+// CostSample is a point on the best-cost trace of a search
+type CostSample struct {
+	Iteration int64         `json:"iteration"`
+	Elapsed   time.Duration `json:"elapsed_ns"`
+	Cost      int           `json:"cost"`
+}
+
+// begin clears the statistics for a new search
+func (s *SearchStats) begin() {
+	*s = SearchStats{start: time.Now()}
+}
+
+// observe adds a sample to the trace when cost beats every cost seen so far
+func (s *SearchStats) observe(cost int) {
+	if k := len(s.BestCosts); k > 0 && cost >= s.BestCosts[k-1].Cost {
+		return
+	}
+	s.BestCosts = append(s.BestCosts, CostSample{
+		Iteration: s.Iterations,
+		Elapsed:   time.Since(s.start),
+		Cost:      cost,
+	})
+}
+
+// bestSeen is embedded by the local search solvers to keep the board with
+// the fewest conflicts of a search. Solvers clear best when a search starts.
+type bestSeen struct {
+	best     []int
+	bestCost int
+}
+
+// recordBest copies board if it beats the best seen so far and adds its
+// cost to the trace in stats
+func (b *bestSeen) recordBest(board []int, cost int, stats *SearchStats) {
+	if b.best == nil {
+		b.best = make([]int, len(board))
+	} else if cost >= b.bestCost {
+		return
+	}
+	copy(b.best, board)
+	b.bestCost = cost
+	stats.observe(cost)
+}
@@ -0,0 +1,46 @@
+package nqueens
+
+// EachSolution calls fn for every solution in search order until fn returns
+// false or limit solutions have been visited (limit <= 0 means no limit).
+// Solutions are produced lazily by the DFS, so nothing is kept in memory;
+// the board passed to fn is reused between calls and must be copied to be
+// retained. It returns the number of solutions visited.
+func (e *ExhaustiveSearchSolver) EachSolution(limit int, fn func(board []int) bool) int64 {
+	var visited int64
+	e.search.run(nil, nil, func(board []int) bool {
+		visited++
+		if !fn(board) {
+			return false
+		}
+		return limit <= 0 || visited < int64(limit)
+	})
+	return visited
+}
+
+// Solutions streams every solution over the returned channel as the DFS
+// finds it, stopping after limit solutions (limit <= 0 means no limit).
+// Each board sent is a fresh copy owned by the receiver. The channel is
+// closed when the search ends; closing done stops the search early and
+// releases the producing goroutine. The stream uses its own search state,
+// so the solver remains usable while it runs.
+func (e *ExhaustiveSearchSolver) Solutions(done <-chan struct{}, limit int) <-chan []int {
+	out := make(chan []int)
+	search := newPlacementSearch(e.n)
+	var sent int64
+
+	go func() {
+		defer close(out)
+		search.run(done, nil, func(board []int) bool {
+			solution := make([]int, len(board))
+			copy(solution, board)
+			select {
+			case out <- solution:
+			case <-done:
+				return false
+			}
+			sent++
+			return limit <= 0 || sent < int64(limit)
+		})
+	}()
+	return out
+}
@@ -0,0 +1,81 @@
+package nqueens_test
+
+import (
+	"fmt"
+	"testing"
+
+	"nqueen/nqueens"
+)
+
+func TestEachSolution(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     int
+		stopAfter int // fn returns false on this call (0 means never)
+		want      int64
+	}{
+		{"all", 0, 0, 92},
+		{"limit", 5, 0, 5},
+		{"limit above count", 200, 0, 92},
+		{"early stop", 0, 3, 3},
+		{"early stop before limit", 5, 3, 3},
+		{"limit before early stop", 3, 5, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seen := make(map[string]bool)
+			calls := 0
+			got := nqueens.NewExhaustiveSearchSolver(8).EachSolution(tt.limit, func(board []int) bool {
+				calls++
+				if nqueens.CountConflicts(board) != 0 {
+					t.Errorf("invalid board %v", board)
+				}
+				seen[fmt.Sprint(board)] = true
+				return calls != tt.stopAfter
+			})
+			if got != tt.want || int64(calls) != tt.want {
+				t.Errorf("EachSolution returned %d after %d calls, want %d", got, calls, tt.want)
+			}
+			if int64(len(seen)) != tt.want {
+				t.Errorf("%d distinct boards, want %d", len(seen), tt.want)
+			}
+		})
+	}
+}
+
+func TestSolutions(t *testing.T) {
+	for _, tt := range []struct {
+		limit int
+		want  int
+	}{{0, 92}, {10, 10}, {92, 92}} {
+		seen := make(map[string]bool)
+		for board := range nqueens.NewExhaustiveSearchSolver(8).Solutions(nil, tt.limit) {
+			if nqueens.CountConflicts(board) != 0 {
+				t.Errorf("limit %d: invalid board %v", tt.limit, board)
+			}
+			seen[fmt.Sprint(board)] = true
+		}
+		if len(seen) != tt.want {
+			t.Errorf("limit %d: received %d distinct boards, want %d", tt.limit, len(seen), tt.want)
+		}
+	}
+}
+
+func TestSolutionsStopsWhenDone(t *testing.T) {
+	done := make(chan struct{})
+	stream := nqueens.NewExhaustiveSearchSolver(12).Solutions(done, 0)
+	for i := 0; i < 3; i++ {
+		<-stream
+	}
+	close(done)
+
+	// The producer may still have a board in flight; after that the
+	// channel must close long before the 14200 solutions are exhausted
+	received := 3
+	for range stream {
+		received++
+	}
+	if total, _ := nqueens.KnownSolutionCount(12); int64(received) >= total {
+		t.Errorf("received all %d solutions after done was closed", received)
+	}
+}
@@ -0,0 +1,138 @@
+package nqueens
+
+// The eight symmetries of the square board map a queen on (r, c) to:
+//
+//	identity        (r, c)
+//	rotate 90       (c, n-1-r)
+//	rotate 180      (n-1-r, n-1-c)
+//	rotate 270      (n-1-c, r)
+//	mirror columns  (r, n-1-c)
+//	mirror rows     (n-1-r, c)
+//	transpose       (c, r)
+//	anti-transpose  (n-1-c, n-1-r)
+//
+// The transposing ones only give a valid one-per-row board when the board is
+// a permutation, which every solution is.
+
+// Symmetries returns the eight images of a permutation board under the
+// rotations and reflections of the square, starting with the board itself
+func Symmetries(board []int) [][]int {
+	images := make([][]int, 8)
+	for k := range images {
+		images[k] = make([]int, len(board))
+		transformBoard(images[k], board, k)
+	}
+	return images
+}
+
+// Canonical returns the lexicographically smallest of the eight symmetric
+// images of a permutation board. Two solutions are equivalent under the
+// board symmetries exactly when their canonical forms are equal.
+func Canonical(board []int) []int {
+	best := make([]int, len(board))
+	copy(best, board)
+	image := make([]int, len(board))
+	for k := 1; k < 8; k++ {
+		transformBoard(image, board, k)
+		if lessBoard(image, best) {
+			copy(best, image)
+		}
+	}
+	return best
+}
+
+// IsCanonical reports whether a permutation board is its own canonical form
+func IsCanonical(board []int) bool {
+	return isCanonical(board, make([]int, len(board)))
+}
+
+// isCanonical is IsCanonical with a caller provided scratch buffer
+func isCanonical(board, scratch []int) bool {
+	for k := 1; k < 8; k++ {
+		transformBoard(scratch, board, k)
+		if lessBoard(scratch, board) {
+			return false
+		}
+	}
+	return true
+}
+
+// transformBoard writes the image of board under symmetry k into dst
+func transformBoard(dst, board []int, k int) {
+	last := len(board) - 1
+	for r, c := range board {
+		switch k {
+		case 0:
+			dst[r] = c
+		case 1:
+			dst[c] = last - r
+		case 2:
+			dst[last-r] = last - c
+		case 3:
+			dst[last-c] = r
+		case 4:
+			dst[r] = last - c
+		case 5:
+			dst[last-r] = c
+		case 6:
+			dst[c] = r
+		case 7:
+			dst[last-c] = last - r
+		}
+	}
+}
+
+// lessBoard compares two boards of equal length lexicographically
+func lessBoard(a, b []int) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+// SymmetryCounts holds the totals of a symmetry-reduced enumeration
+type SymmetryCounts struct {
+	Total  int64 // All solutions (OEIS A000170)
+	Unique int64 // Fundamental solutions, unique up to symmetry (OEIS A002562)
+}
+
+// EnumerateFundamental enumerates the fundamental solutions. Only the left
+// half of the first row (plus the middle column on odd boards) is explored:
+// mirroring the columns maps those solutions onto the rest, so each one
+// found there counts twice towards the total. Every symmetry class keeps its
+// canonical member in the explored half, so a solution is counted as unique
+// exactly when it is canonical, and no set of seen solutions is needed.
+//
+// Each fundamental solution is passed to visit (which may be nil) in
+// canonical form. Returning false from visit stops the enumeration early,
+// leaving the counts partial.
+func (e *ExhaustiveSearchSolver) EnumerateFundamental(visit func(board []int) bool) SymmetryCounts {
+	var counts SymmetryCounts
+	scratch := make([]int, e.n)
+	stopped := false
+
+	for col := 0; col < (e.n+1)/2 && !stopped; col++ {
+		// The middle column of an odd board is its own mirror image
+		weight := int64(2)
+		if 2*col+1 == e.n {
+			weight = 1
+		}
+
+		e.search.run(nil, []int{col}, func(board []int) bool {
+			counts.Total += weight
+			if !isCanonical(board, scratch) {
+				return true
+			}
+			counts.Unique++
+			if visit != nil && !visit(board) {
+				stopped = true
+			}
+			return !stopped
+		})
+	}
+
+	e.solutionCount = counts.Total
+	return counts
+}
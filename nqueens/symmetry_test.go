@@ -0,0 +1,80 @@
+package nqueens_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"nqueen/nqueens"
+)
+
+func TestEnumerateFundamentalMatchesKnownCounts(t *testing.T) {
+	maxN := 14
+	if testing.Short() {
+		maxN = 11
+	}
+	for n := 1; n <= maxN; n++ {
+		solver := nqueens.NewExhaustiveSearchSolver(n)
+		seen := make(map[string]bool)
+		counts := solver.EnumerateFundamental(func(board []int) bool {
+			if !nqueens.IsCanonical(board) {
+				t.Errorf("N=%d: %v is not canonical", n, board)
+			}
+			if conflicts := nqueens.CountConflicts(board); conflicts != 0 {
+				t.Errorf("N=%d: %v has %d conflicts", n, board, conflicts)
+			}
+			seen[fmt.Sprint(board)] = true
+			return true
+		})
+
+		total, _ := nqueens.KnownSolutionCount(n)
+		unique, _ := nqueens.KnownFundamentalCount(n)
+		if counts.Total != total || counts.Unique != unique {
+			t.Errorf("N=%d: counts %+v, want Total %d, Unique %d", n, counts, total, unique)
+		}
+		if int64(len(seen)) != counts.Unique {
+			t.Errorf("N=%d: visited %d distinct boards for %d unique solutions", n, len(seen), counts.Unique)
+		}
+	}
+}
+
+func TestEnumerateFundamentalStopsEarly(t *testing.T) {
+	visited := 0
+	counts := nqueens.NewExhaustiveSearchSolver(10).EnumerateFundamental(func([]int) bool {
+		visited++
+		return visited < 3
+	})
+	if visited != 3 || counts.Unique != 3 {
+		t.Errorf("visited %d boards, Unique = %d, want 3", visited, counts.Unique)
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	// The images of the fundamental solutions of N=8 are all 92 solutions,
+	// and each image has the fundamental solution as its canonical form
+	images := make(map[string]bool)
+	nqueens.NewExhaustiveSearchSolver(8).EnumerateFundamental(func(board []int) bool {
+		symmetries := nqueens.Symmetries(board)
+		if len(symmetries) != 8 {
+			t.Fatalf("Symmetries(%v) returned %d images", board, len(symmetries))
+		}
+		for _, image := range symmetries {
+			if conflicts := nqueens.CountConflicts(image); conflicts != 0 {
+				t.Errorf("image %v of %v has %d conflicts", image, board, conflicts)
+			}
+			if got := nqueens.Canonical(image); !slices.Equal(got, board) {
+				t.Errorf("Canonical(%v) = %v, want %v", image, got, board)
+			}
+			if nqueens.IsCanonical(image) != slices.Equal(image, board) {
+				t.Errorf("IsCanonical(%v) = %v for the class of %v", image, nqueens.IsCanonical(image), board)
+			}
+			images[fmt.Sprint(image)] = true
+		}
+		return true
+	})
+
+	want, _ := nqueens.KnownSolutionCount(8)
+	if int64(len(images)) != want {
+		t.Errorf("%d distinct images, want %d", len(images), want)
+	}
+}
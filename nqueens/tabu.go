@@ -0,0 +1,215 @@
+package nqueens
+
+import (
+	"context"
+	"fmt"
+)
+
+// TabuSearchSolver implements tabu search. Each iteration makes the best
+// move or swap involving a conflicted queen, even when it makes the board
+// worse, so the search climbs out of the local optima where GreedySolver
+// has to restart. To keep it from walking straight back, a queen may not
+// return to a row it left within the last tenure iterations, unless doing
+// so beats the best cost found (aspiration).
+type TabuSearchSolver struct {
+	n             int
+	board         []int       // Current board, owned by state
+	state         *boardState // Conflict counters for board
+	solution      []int
+	solved        bool
+	bestSeen          // Board with the fewest conflicts seen
+	tenure        int // Iterations a queen may not return to a row it left
+	maxIterations int
+	tabu          map[tabuKey]int64 // Iteration until which each (column, row) is tabu
+	stats         SearchStats
+	trace         tracer
+	seededSource
+}
+
+// tabuKey is a queen's column and a row it is barred from
+type tabuKey struct {
+	col, row int
+}
+
+// NewTabuSearchSolver creates a new tabu search solver
+func NewTabuSearchSolver(n int) *TabuSearchSolver {
+	state := newBoardState(n)
+	ts := &TabuSearchSolver{
+		n:             n,
+		board:         state.board,
+		state:         state,
+		tenure:        10 + n/10,
+		maxIterations: max(10*n, 1000),
+	}
+	ts.SetSeed(newSeed())
+	return ts
+}
+
+// applyOptions overrides the defaults with any non-zero registry options
+func (ts *TabuSearchSolver) applyOptions(opts Options) {
+	if opts.Seed != 0 {
+		ts.SetSeed(opts.Seed)
+	}
+	if opts.MaxIterations > 0 {
+		ts.maxIterations = opts.MaxIterations
+	}
+	if opts.Tenure > 0 {
+		ts.tenure = opts.Tenure
+	}
+}
+
+// SetObserver reports the conflicts on the board every interval iterations
+func (ts *TabuSearchSolver) SetObserver(obs Observer, interval int) {
+	ts.trace = newTracer(obs, interval)
+}
+
+// Solve attempts to find a solution using tabu search
+func (ts *TabuSearchSolver) Solve() bool {
+	solved, _ := ts.SolveContext(context.Background())
+	return solved
+}
+
+// SolveContext is Solve bounded by ctx, checked once per iteration
+func (ts *TabuSearchSolver) SolveContext(ctx context.Context) (bool, error) {
+	done := ctx.Done()
+	ts.best = nil
+	ts.solution = nil
+	ts.solved = false
+	ts.tabu = make(map[tabuKey]int64)
+	ts.stats.begin()
+
+	// Start from a random permutation, so only diagonals conflict
+	ts.state.reset(ts.rng.Perm(ts.n))
+	ts.recordBest(ts.board, ts.state.cost, &ts.stats)
+
+	for iter := int64(1); ts.state.cost > 0 && iter <= int64(ts.maxIterations); iter++ {
+		if isDone(done) {
+			return false, canceledError(ctx)
+		}
+		ts.stats.Iterations++
+
+		move, ok := ts.bestMove(iter)
+		if !ok {
+			// Every move is tabu: forget the list rather than stall
+			ts.tabu = make(map[tabuKey]int64)
+			continue
+		}
+		ts.makeTabu(move, iter)
+		move.apply(ts.state)
+		ts.stats.Accepted++
+		ts.recordBest(ts.board, ts.state.cost, &ts.stats)
+
+		if ts.trace.due(ts.stats.Iterations) {
+			ts.trace.observe(TracePoint{
+				Iteration: ts.stats.Iterations,
+				Cost:      ts.state.cost,
+				BestCost:  ts.bestCost,
+			})
+		}
+		if iter%int64(ts.tenure+1) == 0 {
+			ts.pruneTabu(iter)
+		}
+	}
+
+	if ts.state.cost > 0 {
+		return false, nil
+	}
+	ts.solution = make([]int, ts.n)
+	copy(ts.solution, ts.board)
+	ts.solved = true
+	return true, nil
+}
+
+// bestMove returns the admissible neighbor with the lowest cost: moving a
+// conflicted queen to another row, or swapping it with another queen.
+// A neighbor is admissible when it is not tabu or when it would beat the
+// best cost so far. Ties are broken at random so that the search does not
+// cycle through the same few boards.
+func (ts *TabuSearchSolver) bestMove(iter int64) (neighborMove, bool) {
+	var best neighborMove
+	bestDelta, ties := 0, 0
+	consider := func(m neighborMove, delta int, tabu bool) {
+		ts.stats.Evaluations++
+		if tabu && ts.state.cost+delta >= ts.bestCost {
+			return
+		}
+		switch {
+		case ties == 0 || delta < bestDelta:
+			best, bestDelta, ties = m, delta, 1
+		case delta == bestDelta:
+			ties++
+			if ts.rng.Intn(ties) == 0 {
+				best = m
+			}
+		}
+	}
+
+	for col := 0; col < ts.n; col++ {
+		if ts.state.conflictsAt(col) == 0 {
+			continue
+		}
+		row := ts.board[col]
+		for r := 0; r < ts.n; r++ {
+			if r != row {
+				consider(neighborMove{col: col, row: r}, ts.state.moveDelta(col, r), ts.isTabu(col, r, iter))
+			}
+		}
+		for other := 0; other < ts.n; other++ {
+			if other != col {
+				tabu := ts.isTabu(col, ts.board[other], iter) || ts.isTabu(other, row, iter)
+				consider(neighborMove{col: col, other: other, swap: true}, ts.state.swapDelta(col, other), tabu)
+			}
+		}
+	}
+	return best, ties > 0
+}
+
+// isTabu reports whether queen col is barred from row at iteration iter
+func (ts *TabuSearchSolver) isTabu(col, row int, iter int64) bool {
+	return ts.tabu[tabuKey{col, row}] >= iter
+}
+
+// makeTabu bars the queens that m moves from their current rows for the
+// next tenure iterations
+func (ts *TabuSearchSolver) makeTabu(m neighborMove, iter int64) {
+	until := iter + int64(ts.tenure)
+	ts.tabu[tabuKey{m.col, ts.board[m.col]}] = until
+	if m.swap {
+		ts.tabu[tabuKey{m.other, ts.board[m.other]}] = until
+	}
+}
+
+// pruneTabu drops expired entries so the list stays within a few tenures
+func (ts *TabuSearchSolver) pruneTabu(iter int64) {
+	for key, until := range ts.tabu {
+		if until < iter {
+			delete(ts.tabu, key)
+		}
+	}
+}
+
+// Stats returns the statistics of the last search. Each iteration makes
+// one move; the evaluations count every neighbor scored, tabu or not.
+func (ts *TabuSearchSolver) Stats() SearchStats {
+	return ts.stats
+}
+
+// Best returns the board with the fewest conflicts seen by the last search
+func (ts *TabuSearchSolver) Best() ([]int, int) {
+	return ts.best, ts.bestCost
+}
+
+// GetSolution returns the found solution
+func (ts *TabuSearchSolver) GetSolution() []int {
+	return ts.solution
+}
+
+// PrintSolution prints the solution board
+func (ts *TabuSearchSolver) PrintSolution() {
+	if !ts.solved {
+		fmt.Println("No solution found")
+		return
+	}
+
+	printBoard("Tabu Search Solution", ts.solution)
+}
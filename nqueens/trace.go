@@ -0,0 +1,88 @@
+package nqueens
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// TracePoint is one sample of a search's convergence. Temperature is only
+// set by simulated annealing and MeanCost only by the genetic algorithm.
+type TracePoint struct {
+	Run         int     `json:"run"`       // 0 for the first run, 1 after the first restart, ...
+	Iteration   int64   `json:"iteration"` // Iterations since the search started, as in SearchStats
+	Cost        int     `json:"cost"`      // Conflicts on the current board (sa, greedy) or fittest chromosome (ga)
+	BestCost    int     `json:"best_cost"` // Fewest conflicts seen so far in this run
+	Temperature float64 `json:"temperature,omitempty"`
+	MeanCost    float64 `json:"mean_cost,omitempty"` // Mean conflicts of the population
+}
+
+// Observer receives trace points while a search runs. It is called on the
+// solver's goroutine and should return quickly.
+type Observer func(TracePoint)
+
+// Traced is implemented by the solvers that can report their convergence:
+// greedy hill climbing, simulated annealing, the genetic algorithm and tabu
+// search
+type Traced interface {
+	// SetObserver calls obs every interval iterations of later searches
+	// (every iteration when interval < 1). A nil obs turns tracing off.
+	SetObserver(obs Observer, interval int)
+}
+
+// tracer holds a solver's observer and sampling interval
+type tracer struct {
+	observe Observer
+	every   int64
+}
+
+func newTracer(obs Observer, interval int) tracer {
+	if interval < 1 {
+		interval = 1
+	}
+	return tracer{observe: obs, every: int64(interval)}
+}
+
+// due reports whether a sample should be taken at iteration
+func (t tracer) due(iteration int64) bool {
+	return t.observe != nil && iteration%t.every == 0
+}
+
+// TraceWriter writes trace points as CSV rows, one per sample, with the
+// header run,iteration,cost,best_cost,temperature,mean_cost
+type TraceWriter struct {
+	w   *csv.Writer
+	err error
+}
+
+// NewTraceWriter starts a CSV trace on w, writing the header row
+func NewTraceWriter(w io.Writer) *TraceWriter {
+	tw := &TraceWriter{w: csv.NewWriter(w)}
+	tw.err = tw.w.Write([]string{"run", "iteration", "cost", "best_cost", "temperature", "mean_cost"})
+	return tw
+}
+
+// Observe writes p; it has the Observer signature so it can be passed to
+// SetObserver directly. Errors are kept for Flush.
+func (tw *TraceWriter) Observe(p TracePoint) {
+	if tw.err != nil {
+		return
+	}
+	tw.err = tw.w.Write([]string{
+		strconv.Itoa(p.Run),
+		strconv.FormatInt(p.Iteration, 10),
+		strconv.Itoa(p.Cost),
+		strconv.Itoa(p.BestCost),
+		strconv.FormatFloat(p.Temperature, 'g', -1, 64),
+		strconv.FormatFloat(p.MeanCost, 'g', -1, 64),
+	})
+}
+
+// Flush writes any buffered rows and returns the first error met
+func (tw *TraceWriter) Flush() error {
+	tw.w.Flush()
+	if tw.err != nil {
+		return tw.err
+	}
+	return tw.w.Error()
+}
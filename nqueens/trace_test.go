@@ -0,0 +1,66 @@
+package nqueens_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"nqueen/nqueens"
+)
+
+func TestObserverInterval(t *testing.T) {
+	for _, name := range []string{"greedy", "sa", "ga", "tabu"} {
+		solver, err := nqueens.NewSolver(name, 10, nqueens.Options{Seed: testSeed})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var points []nqueens.TracePoint
+		solver.(nqueens.Traced).SetObserver(func(p nqueens.TracePoint) {
+			points = append(points, p)
+		}, 2)
+		solver.Solve()
+
+		if len(points) == 0 {
+			t.Errorf("%s: no trace points", name)
+			continue
+		}
+		for _, p := range points {
+			if p.Iteration%2 != 0 || p.BestCost > p.Cost {
+				t.Errorf("%s: unexpected trace point %+v", name, p)
+				break
+			}
+		}
+		if got, want := int64(len(points)), solver.Stats().Iterations/2; got != want {
+			t.Errorf("%s: %d trace points for %d iterations", name, got, solver.Stats().Iterations)
+		}
+	}
+}
+
+func TestTraceWriter(t *testing.T) {
+	var buf bytes.Buffer
+	tw := nqueens.NewTraceWriter(&buf)
+	tw.Observe(nqueens.TracePoint{Run: 1, Iteration: 10, Cost: 4, BestCost: 2, Temperature: 0.5})
+	if err := tw.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		{"run", "iteration", "cost", "best_cost", "temperature", "mean_cost"},
+		{"1", "10", "4", "2", "0.5", "0"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(rows), len(want))
+	}
+	for i := range want {
+		for j := range want[i] {
+			if rows[i][j] != want[i][j] {
+				t.Errorf("row %d = %v, want %v", i, rows[i], want[i])
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package nqueens
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ConflictKind is the line along which two queens attack each other
+type ConflictKind int
+
+const (
+	RowConflict          ConflictKind = iota // Same row (equal board values)
+	ColumnConflict                           // Same column (equal board indices)
+	DiagonalConflict                         // Same col - row diagonal
+	AntiDiagonalConflict                     // Same col + row diagonal
+)
+
+var conflictKindNames = [...]string{"row", "column", "diagonal", "anti-diagonal"}
+
+// String returns the name of the line, e.g. "anti-diagonal"
+func (k ConflictKind) String() string {
+	if k < 0 || int(k) >= len(conflictKindNames) {
+		return fmt.Sprintf("ConflictKind(%d)", int(k))
+	}
+	return conflictKindNames[k]
+}
+
+// MarshalText encodes the kind by name, e.g. in JSON reports
+func (k ConflictKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// Position is a queen's square on the board
+type Position struct {
+	Col int `json:"col"`
+	Row int `json:"row"`
+}
+
+// Conflict is one pair of queens attacking each other
+type Conflict struct {
+	Kind ConflictKind `json:"kind"`
+	A    Position     `json:"a"`
+	B    Position     `json:"b"`
+}
+
+// String describes the pair as "kind: (col,row) and (col,row)"
+func (c Conflict) String() string {
+	return fmt.Sprintf("%s: (%d,%d) and (%d,%d)", c.Kind, c.A.Col, c.A.Row, c.B.Col, c.B.Row)
+}
+
+// MaxReportedConflicts caps the attacking pairs listed in a ValidationReport.
+// Every pair is still counted, but a board with most queens on one line has
+// O(N²) of them, too many to list for large N.
+const MaxReportedConflicts = 1000
+
+// ValidationReport is the outcome of checking a board independently of any
+// solver's own cost function
+type ValidationReport struct {
+	N         int        `json:"n"`
+	Valid     bool       `json:"valid"`
+	Total     int        `json:"total"`     // Attacking pairs of all kinds
+	Counts    [4]int     `json:"counts"`    // Attacking pairs per ConflictKind
+	Conflicts []Conflict `json:"conflicts"` // The first MaxReportedConflicts pairs
+	Truncated bool       `json:"truncated,omitempty"`
+	OffBoard  []Position `json:"off_board,omitempty"` // Queens outside the N×N board
+}
+
+// Validate checks a board holding one queen per column, board[col] being
+// its row. The board is N×N with N = len(board); it need not be a
+// permutation. A valid board has every queen on the board and no two
+// queens attacking each other. Validate runs in O(N) plus the number of
+// pairs listed.
+func Validate(board []int) ValidationReport {
+	positions := make([]Position, len(board))
+	for col, row := range board {
+		positions[col] = Position{Col: col, Row: row}
+	}
+	return ValidatePositions(len(board), positions)
+}
+
+// ValidatePositions checks an arbitrary set of queens on an N×N board, so
+// unlike Validate it can also report column conflicts. Valid requires
+// exactly N queens.
+func ValidatePositions(n int, positions []Position) ValidationReport {
+	r := ValidationReport{N: n}
+
+	// Count the queens on every line, indexed like boardState's counters,
+	// then gather the queens of the crowded lines only
+	lines := 2*n - 1
+	if lines < 0 {
+		lines = 0
+	}
+	counts := [4][]int32{
+		RowConflict:          make([]int32, n),
+		ColumnConflict:       make([]int32, n),
+		DiagonalConflict:     make([]int32, lines),
+		AntiDiagonalConflict: make([]int32, lines),
+	}
+	lineOf := func(kind int, p Position) int {
+		switch ConflictKind(kind) {
+		case RowConflict:
+			return p.Row
+		case ColumnConflict:
+			return p.Col
+		case DiagonalConflict:
+			return p.Col - p.Row + n - 1
+		}
+		return p.Col + p.Row
+	}
+
+	onBoard := positions[:0:0]
+	for _, p := range positions {
+		if p.Col < 0 || p.Col >= n || p.Row < 0 || p.Row >= n {
+			r.OffBoard = append(r.OffBoard, p)
+			continue
+		}
+		onBoard = append(onBoard, p)
+		for kind := range counts {
+			counts[kind][lineOf(kind, p)]++
+		}
+	}
+
+	for kind := range counts {
+		crowded := make(map[int][]Position)
+		for _, p := range onBoard {
+			line := lineOf(kind, p)
+			if counts[kind][line] > 1 {
+				crowded[line] = append(crowded[line], p)
+			}
+		}
+		for _, line := range sortedKeys(crowded) {
+			queens := crowded[line]
+			k := len(queens)
+			r.Counts[kind] += k * (k - 1) / 2
+			r.addPairs(ConflictKind(kind), queens)
+		}
+		r.Total += r.Counts[kind]
+	}
+
+	r.Valid = r.Total == 0 && len(r.OffBoard) == 0 && len(positions) == n
+	return r
+}
+
+// addPairs lists every pair of queens on one line, up to MaxReportedConflicts
+func (r *ValidationReport) addPairs(kind ConflictKind, queens []Position) {
+	for x := range queens {
+		for y := x + 1; y < len(queens); y++ {
+			if len(r.Conflicts) == MaxReportedConflicts {
+				r.Truncated = true
+				return
+			}
+			r.Conflicts = append(r.Conflicts, Conflict{Kind: kind, A: queens[x], B: queens[y]})
+		}
+	}
+}
+
+// sortedKeys returns the keys of m in increasing order, so that reports
+// list conflicts deterministically
+func sortedKeys(m map[int][]Position) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+// String summarizes the report on one line
+func (r ValidationReport) String() string {
+	if r.Valid {
+		return fmt.Sprintf("valid (N=%d)", r.N)
+	}
+
+	var parts []string
+	for kind, count := range r.Counts {
+		if count > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", count, ConflictKind(kind)))
+		}
+	}
+	if len(r.OffBoard) > 0 {
+		parts = append(parts, fmt.Sprintf("%d off the board", len(r.OffBoard)))
+	}
+	if len(parts) == 0 {
+		return fmt.Sprintf("invalid (N=%d): wrong number of queens", r.N)
+	}
+	return fmt.Sprintf("invalid (N=%d): %d attacking pairs (%s)", r.N, r.Total, strings.Join(parts, ", "))
+}
@@ -0,0 +1,78 @@
+package nqueens_test
+
+import (
+	"testing"
+
+	"nqueen/nqueens"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		board    []int
+		valid    bool
+		counts   [4]int
+		offBoard int
+	}{
+		{"solution", []int{1, 3, 0, 2}, true, [4]int{}, 0},
+		{"empty", []int{}, true, [4]int{}, 0},
+		{"main diagonal", []int{0, 1, 2, 3}, false, [4]int{nqueens.DiagonalConflict: 6}, 0},
+		{"anti-diagonal", []int{2, 1, 0}, false, [4]int{nqueens.AntiDiagonalConflict: 3}, 0},
+		{"shared row", []int{0, 0, 3, 1}, false, [4]int{nqueens.RowConflict: 1}, 0},
+		{"off board", []int{1, 3, 0, -1}, false, [4]int{}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := nqueens.Validate(tt.board)
+			if report.Valid != tt.valid {
+				t.Errorf("Valid = %v, want %v (%v)", report.Valid, tt.valid, report)
+			}
+			if report.Counts != tt.counts {
+				t.Errorf("Counts = %v, want %v", report.Counts, tt.counts)
+			}
+			if len(report.OffBoard) != tt.offBoard {
+				t.Errorf("OffBoard = %v, want %d queens", report.OffBoard, tt.offBoard)
+			}
+			if total := len(report.Conflicts); total != report.Total {
+				t.Errorf("listed %d conflicts, Total = %d", total, report.Total)
+			}
+		})
+	}
+}
+
+func TestValidateAgreesWithCountConflicts(t *testing.T) {
+	boards := [][]int{
+		{0, 0, 0, 0, 0},
+		{4, 2, 0, 3, 1},
+		{1, 1, 2, 6, 4, 0, 5},
+		{0, 2, 4, 6, 1, 3, 5, 7},
+	}
+	for _, board := range boards {
+		if got, want := nqueens.Validate(board).Total, nqueens.CountConflicts(board); got != want {
+			t.Errorf("Validate(%v).Total = %d, CountConflicts = %d", board, got, want)
+		}
+	}
+}
+
+func TestValidatePositionsColumnConflict(t *testing.T) {
+	positions := []nqueens.Position{{Col: 0, Row: 1}, {Col: 0, Row: 3}, {Col: 2, Row: 0}, {Col: 3, Row: 2}}
+	report := nqueens.ValidatePositions(4, positions)
+	if report.Valid {
+		t.Fatal("two queens in column 0 reported valid")
+	}
+	if got := report.Counts[nqueens.ColumnConflict]; got != 1 {
+		t.Errorf("column conflicts = %d, want 1", got)
+	}
+}
+
+func TestValidateTruncatesConflictList(t *testing.T) {
+	board := make([]int, 100) // Every queen in row 0
+	report := nqueens.Validate(board)
+	if want := 100 * 99 / 2; report.Total != want {
+		t.Errorf("Total = %d, want %d", report.Total, want)
+	}
+	if !report.Truncated || len(report.Conflicts) != nqueens.MaxReportedConflicts {
+		t.Errorf("listed %d conflicts (truncated %v), want %d", len(report.Conflicts), report.Truncated, nqueens.MaxReportedConflicts)
+	}
+}
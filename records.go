@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+// recordFormats are the output formats of solve and compare. csv and jsonl
+// write one flat record per run, for loading into a dataframe.
+var recordFormats = []string{"text", "json", "csv", "jsonl"}
+
+// runRecord is the flat form of a runResult written by the csv and jsonl
+// formats, without the solution board
+type runRecord struct {
+	Algorithm   string  `json:"algorithm"`
+	N           int     `json:"n"`
+	Trial       int     `json:"trial"`
+	Seed        int64   `json:"seed"`
+	DurationNS  int64   `json:"duration_ns"`
+	TotalAlloc  uint64  `json:"total_alloc"`
+	HeapAlloc   uint64  `json:"heap_alloc"`
+	Success     bool    `json:"success"`
+	Iterations  int64   `json:"iterations"`
+	Restarts    int     `json:"restarts"`
+	Evaluations int64   `json:"evaluations"`
+	Accepted    int64   `json:"accepted"`
+	Rejected    int64   `json:"rejected"`
+	Nodes       int64   `json:"nodes"`
+	Backtracks  int64   `json:"backtracks"`
+	InitialTemp float64 `json:"initial_temp"`
+	Conflicts   int     `json:"conflicts"`
+	TimedOut    bool    `json:"timed_out"`
+	Skipped     bool    `json:"skipped"`
+	Invalid     bool    `json:"invalid"`
+}
+
+// recordHeader names the CSV columns, in the order of csvRow
+var recordHeader = []string{
+	"algorithm", "n", "trial", "seed", "duration_ns", "total_alloc", "heap_alloc",
+	"success", "iterations", "restarts", "evaluations", "accepted", "rejected",
+	"nodes", "backtracks", "initial_temp", "conflicts", "timed_out", "skipped", "invalid",
+}
+
+func newRunRecord(r runResult) runRecord {
+	return runRecord{
+		Algorithm:   r.Algorithm,
+		N:           r.N,
+		Trial:       r.Trial,
+		Seed:        r.Seed,
+		DurationNS:  r.DurationNS,
+		TotalAlloc:  r.TotalAlloc,
+		HeapAlloc:   r.HeapAlloc,
+		Success:     r.Success,
+		Iterations:  r.Stats.Iterations,
+		Restarts:    r.Stats.Restarts,
+		Evaluations: r.Stats.Evaluations,
+		Accepted:    r.Stats.Accepted,
+		Rejected:    r.Stats.Rejected,
+		Nodes:       r.Stats.Nodes,
+		Backtracks:  r.Stats.Backtracks,
+		InitialTemp: r.Stats.InitialTemp,
+		Conflicts:   r.Conflicts,
+		TimedOut:    r.TimedOut,
+		Skipped:     r.Skipped,
+		Invalid:     r.Invalid,
+	}
+}
+
+func (r runRecord) csvRow() []string {
+	return []string{
+		r.Algorithm,
+		strconv.Itoa(r.N),
+		strconv.Itoa(r.Trial),
+		strconv.FormatInt(r.Seed, 10),
+		strconv.FormatInt(r.DurationNS, 10),
+		strconv.FormatUint(r.TotalAlloc, 10),
+		strconv.FormatUint(r.HeapAlloc, 10),
+		strconv.FormatBool(r.Success),
+		strconv.FormatInt(r.Iterations, 10),
+		strconv.Itoa(r.Restarts),
+		strconv.FormatInt(r.Evaluations, 10),
+		strconv.FormatInt(r.Accepted, 10),
+		strconv.FormatInt(r.Rejected, 10),
+		strconv.FormatInt(r.Nodes, 10),
+		strconv.FormatInt(r.Backtracks, 10),
+		strconv.FormatFloat(r.InitialTemp, 'g', -1, 64),
+		strconv.Itoa(r.Conflicts),
+		strconv.FormatBool(r.TimedOut),
+		strconv.FormatBool(r.Skipped),
+		strconv.FormatBool(r.Invalid),
+	}
+}
+
+// recordWriter writes runs as CSV rows or JSON Lines. Each record is
+// flushed as it is written, so the output of a long comparison can be
+// followed while it runs.
+type recordWriter struct {
+	csv *csv.Writer
+	enc *json.Encoder
+}
+
+// newRecordWriter starts a csv or jsonl stream on w, writing the CSV header
+func newRecordWriter(w io.Writer, format string) (*recordWriter, error) {
+	switch format {
+	case "csv":
+		rw := &recordWriter{csv: csv.NewWriter(w)}
+		rw.csv.Write(recordHeader)
+		rw.csv.Flush()
+		return rw, rw.csv.Error()
+	case "jsonl":
+		return &recordWriter{enc: json.NewEncoder(w)}, nil
+	}
+	return nil, fmt.Errorf("format %q does not write records", format)
+}
+
+// write appends the record of one run
+func (rw *recordWriter) write(r runResult) error {
+	rec := newRunRecord(r)
+	if rw.enc != nil {
+		return rw.enc.Encode(rec)
+	}
+	rw.csv.Write(rec.csvRow())
+	rw.csv.Flush()
+	return rw.csv.Error()
+}
+
+// addOutFlag registers the flag naming the file that receives json, csv or
+// jsonl output
+func addOutFlag(fs *flag.FlagSet) *string {
+	return fs.String("out", "", "write json, csv or jsonl output to this file instead of standard output")
+}
+
+// openOutput opens the destination of machine-readable output: the file at
+// path, or standard output when path is empty. Text output always goes to
+// standard output. The returned function closes the file.
+func openOutput(path, format string) (io.Writer, func() error, error) {
+	if path == "" {
+		return os.Stdout, func() error { return nil }, nil
+	}
+	if format == "text" {
+		return nil, nil, fmt.Errorf("-out needs -format json, csv or jsonl")
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, f.Close, nil
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"nqueen/nqueens"
+)
+
+// runSolve solves a single board size with one algorithm
+func runSolve(args []string) (err error) {
+	fs := flag.NewFlagSet("solve", flag.ExitOnError)
+	algoFlag := fs.String("algo", "sa", "algorithm name")
+	n := fs.Int("n", 8, "board size")
+	showBoard := fs.Bool("board", false, "print the solution board even for N > 20")
+	common := addCommonFlags(fs, recordFormats...)
+	outPath := addOutFlag(fs)
+	tracePath := fs.String("trace", "", "write a CSV convergence trace to this `file` (greedy, sa, ga, tabu)")
+	traceEvery := fs.Int("trace-every", 1, "iterations between trace samples")
+	opts := addAlgorithmFlags(fs)
+	fs.Parse(args)
+
+	if err := common.setup(); err != nil {
+		return err
+	}
+	if err := loadConfig(fs, opts); err != nil {
+		return err
+	}
+	if *n < 1 {
+		return fmt.Errorf("invalid board size %d", *n)
+	}
+	algos, err := lookupAlgorithms(*algoFlag)
+	if err != nil {
+		return err
+	}
+	if len(algos) != 1 {
+		return fmt.Errorf("solve takes exactly one algorithm, got %q", *algoFlag)
+	}
+	algo := algos[0]
+	if err := validateOptions(opts, algos, *n); err != nil {
+		return err
+	}
+	resolveSeed(opts)
+
+	out, closeOut, err := openOutput(*outPath, common.format)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := closeOut(); err == nil {
+			err = cerr
+		}
+	}()
+
+	if *tracePath != "" {
+		trace, err := traceAlgorithm(&algo, *tracePath, *traceEvery)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if cerr := trace(); err == nil {
+				err = cerr
+			}
+		}()
+	}
+
+	result, solver := measureRun(algo, *n, *opts, common.timeout)
+	failed := solveError(result, common.timeout)
+	switch common.format {
+	case "json":
+		if err := writeJSON(out, result); err != nil {
+			return err
+		}
+		return failed
+	case "csv", "jsonl":
+		records, err := newRecordWriter(out, common.format)
+		if err != nil {
+			return err
+		}
+		if err := records.write(result); err != nil {
+			return err
+		}
+		return failed
+	}
+
+	printResultLine(algo.DisplayName, result)
+	if result.Seed != 0 {
+		fmt.Printf("Seed: %d\n", result.Seed)
+	}
+	printStats(result.Stats)
+	if result.Invalid {
+		for _, c := range result.Validation.Conflicts {
+			fmt.Printf("  %v\n", c)
+		}
+	}
+	if result.Success && (*n <= 20 || *showBoard) {
+		solver.PrintSolution()
+	}
+	return failed
+}
+
+// solveError turns a run without a valid solution into an error, so that
+// scripts can tell a missed deadline or a failed search by the exit status
+func solveError(r runResult, timeout time.Duration) error {
+	switch {
+	case r.TimedOut:
+		return fmt.Errorf("%s timed out on N=%d after %v (best: %d conflicts)", r.Algorithm, r.N, timeout, r.Conflicts)
+	case r.Invalid:
+		return fmt.Errorf("%s reported an invalid solution for N=%d", r.Algorithm, r.N)
+	case !r.Success:
+		return fmt.Errorf("%s found no solution for N=%d (best: %d conflicts)", r.Algorithm, r.N, r.Conflicts)
+	}
+	return nil
+}
+
+// printStats prints the search statistics of a run, leaving out the
+// counters the algorithm does not use
+func printStats(s nqueens.SearchStats) {
+	counters := []struct {
+		name  string
+		value int64
+	}{
+		{"nodes", s.Nodes},
+		{"backtracks", s.Backtracks},
+		{"iterations", s.Iterations},
+		{"restarts", int64(s.Restarts)},
+		{"evaluations", s.Evaluations},
+		{"accepted", s.Accepted},
+		{"rejected", s.Rejected},
+	}
+	var parts []string
+	for _, c := range counters {
+		if c.value != 0 {
+			parts = append(parts, fmt.Sprintf("%s %d", c.name, c.value))
+		}
+	}
+	if len(parts) > 0 {
+		fmt.Printf("Stats: %s\n", strings.Join(parts, ", "))
+	}
+	if s.InitialTemp > 0 {
+		fmt.Printf("Calibrated initial temperature: %.4g\n", s.InitialTemp)
+	}
+	if k := len(s.BestCosts); k > 0 {
+		first, last := s.BestCosts[0], s.BestCosts[k-1]
+		fmt.Printf("Best cost: %d -> %d in %d improvements (last at iteration %d, %v)\n",
+			first.Cost, last.Cost, k-1, last.Iteration, last.Elapsed.Round(time.Microsecond))
+	}
+}
+
+// traceAlgorithm makes the solvers built by algo write a CSV convergence
+// trace to path, sampling every interval iterations. Writing the trace is
+// part of the measured run time. The returned function flushes and closes
+// the file.
+func traceAlgorithm(algo *nqueens.Algorithm, path string, interval int) (func() error, error) {
+	if _, ok := algo.New(1, nqueens.Options{}).(nqueens.Traced); !ok {
+		return nil, fmt.Errorf("algorithm %q does not support tracing", algo.Name)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	trace := nqueens.NewTraceWriter(f)
+
+	newSolver := algo.New
+	algo.New = func(n int, opts nqueens.Options) nqueens.Solver {
+		s := newSolver(n, opts)
+		s.(nqueens.Traced).SetObserver(trace.Observe, interval)
+		return s
+	}
+	return func() error {
+		err := trace.Flush()
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		return err
+	}, nil
+}